@@ -0,0 +1,355 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// approvalRequoteTolerance how much more than the approved price a re-quoted fleet may charge to still be considered,
+// any price change is sent back for approval
+var approvalRequoteTolerance = util.FareTolerance{Percent: 10}
+
+// errApprovalRequoted the quote of an approved request expired and the re-quoted one needs a new approval
+var errApprovalRequoted = util.NewGatewayError(http.StatusConflict, util.GatewayErrorReapprovalRequired,
+	"quote expired, the re-quoted price or policy violations have to be approved again")
+
+// errNoSuchApproval an approval request that does not exist, or that another client requested
+var errNoSuchApproval = util.NewGatewayError(http.StatusNotFound, util.GatewayErrorNotFound, "approval request not found")
+
+// errApprovalLedgerRequired approval requests wait for a decision in the ledger
+var errApprovalLedgerRequired = errors.New("the ledger is unavailable, it keeps the approval requests")
+
+// approvalNotifier gets notified about every approval request change, replace it to send emails, slack messages etc.
+var approvalNotifier util.ApprovalNotifier = func(r *util.ApprovalRequest) {
+	util.LogEvent(util.LevelInfo, "approval request changed", util.LogField("approval_id", r.ID), util.LogField("status", r.Status))
+}
+
+// requestBooking books the quote right away if it is within policy, otherwise parks it in the ledger as a pending
+// approval request of client
+func requestBooking(a *util.AuthInfo, client string, policy util.TravelPolicy, quotesList *util.QuotesList,
+	origin, destination util.Geolocation, pickupTime string, bookingRequest *util.BookingRequest) (*util.BookingDetails, *util.ApprovalRequest, error) {
+	quoteID := bookingRequest.QuoteID
+	var quote *util.Quote
+	for i := range quotesList.Quotes {
+		if quotesList.Quotes[i].ID == quoteID {
+			quote = &quotesList.Quotes[i]
+			break
+		}
+	}
+	if quote == nil {
+		return nil, nil, fmt.Errorf("quote %s not found in quotes list %s", quoteID, quotesList.ID)
+	}
+	err := bookingRequest.ValidateForQuote(*quote)
+	if err != nil {
+		return nil, nil, err
+	}
+	violations := policy.Violations(*quote)
+	if len(violations) == 0 {
+		bookingDetails, err := bookATrip(a, bookingRequest)
+		if err != nil {
+			return nil, nil, err
+		}
+		recordQuoteSelection(*quote)
+		return bookingDetails, nil, nil
+	}
+	if bookingLedger == nil {
+		return nil, nil, errApprovalLedgerRequired
+	}
+
+	now := time.Now()
+	r := &util.ApprovalRequest{
+		ID:             util.GenerateID(),
+		Status:         util.ApprovalPending,
+		Client:         client,
+		QuoteID:        quote.ID,
+		FleetID:        quote.Fleet.ID,
+		FleetName:      quote.Fleet.Name,
+		VehicleClass:   quote.Vehicle.Class,
+		Price:          quote.Price.High,
+		CurrencyCode:   quote.Price.CurrencyCode,
+		Violations:     violations,
+		Policy:         policy,
+		Origin:         origin,
+		Destination:    destination,
+		PickupTime:     pickupTime,
+		BookingRequest: bookingRequest,
+		CreatedAt:      now,
+		ExpiresAt:      util.QuoteExpiration(now, quotesList.Validity),
+	}
+	err = bookingLedger.RecordApproval(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	approvalNotifier(r)
+	return nil, r, nil
+}
+
+// getApprovalRequest returns the approval request with the given ID
+func getApprovalRequest(approvalID string) (*util.ApprovalRequest, error) {
+	if bookingLedger == nil {
+		return nil, errApprovalLedgerRequired
+	}
+	r, err := bookingLedger.Approval(approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, errNoSuchApproval
+	}
+	return r, nil
+}
+
+// listPendingApprovals returns all approval requests still waiting for a decision, oldest first
+func listPendingApprovals() ([]*util.ApprovalRequest, error) {
+	if bookingLedger == nil {
+		return nil, errApprovalLedgerRequired
+	}
+	return bookingLedger.Approvals(util.ApprovalPending)
+}
+
+// approveBooking approves a pending request and books it, re-quoting the same route if the quote has lapsed. A
+// re-quoted price that differs from the approved one is sent back to pending with errApprovalRequoted
+func approveBooking(a *util.AuthInfo, approvalID, approver string) (*util.BookingDetails, error) {
+	r, err := takePendingApproval(approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Expired(time.Now()) {
+		reapprove, err := requote(a, r)
+		if err != nil {
+			decideApproval(r, util.ApprovalExpired, approver, err.Error())
+			return nil, err
+		}
+		if reapprove {
+			releaseApproval(r)
+			return nil, errApprovalRequoted
+		}
+	}
+	r.BookingRequest.QuoteID = r.QuoteID
+	bookingDetails, err := bookATrip(a, r.BookingRequest)
+	if err != nil {
+		// leave the request pending so that it can be approved again
+		releaseApproval(r)
+		return nil, err
+	}
+	recordQuoteSelection(r.ApprovedQuote())
+	r.BookingID = bookingDetails.ID
+	decideApproval(r, util.ApprovalApproved, approver, "")
+	return bookingDetails, nil
+}
+
+// rejectBooking rejects a pending request, nothing gets booked
+func rejectBooking(approvalID, approver, reason string) error {
+	r, err := takePendingApproval(approvalID)
+	if err != nil {
+		return err
+	}
+	decideApproval(r, util.ApprovalRejected, approver, reason)
+	return nil
+}
+
+// takePendingApproval marks a pending request as being decided on so that it can not be approved or rejected twice,
+// also not by another process sharing the ledger
+func takePendingApproval(approvalID string) (*util.ApprovalRequest, error) {
+	r, err := getApprovalRequest(approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != util.ApprovalPending {
+		return nil, approvalDecidedError(approvalID, string(r.Status))
+	}
+	r.Status = util.ApprovalInProgress
+	ok, err := bookingLedger.UpdateApproval(r, util.ApprovalPending)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, approvalDecidedError(approvalID, "being decided on")
+	}
+	return r, nil
+}
+
+func approvalDecidedError(approvalID, status string) error {
+	return util.NewGatewayError(http.StatusConflict, util.GatewayErrorApprovalDecided,
+		fmt.Sprintf("approval request %s is already %s", approvalID, status))
+}
+
+// releaseApproval sends a request being decided on back to pending
+func releaseApproval(r *util.ApprovalRequest) {
+	r.Status = util.ApprovalPending
+	saveApproval(r)
+}
+
+func decideApproval(r *util.ApprovalRequest, status util.ApprovalStatus, approver, reason string) {
+	r.Status = status
+	r.DecidedBy = approver
+	r.DecidedAt = time.Now()
+	r.Reason = reason
+	saveApproval(r)
+}
+
+// saveApproval records the change of a request taken by takePendingApproval and notifies about it
+func saveApproval(r *util.ApprovalRequest) {
+	ok, err := bookingLedger.UpdateApproval(r, util.ApprovalInProgress)
+	if err == nil && !ok {
+		err = fmt.Errorf("approval request %s is no longer in progress", r.ID)
+	}
+	ledgerWarning(err)
+	approvalNotifier(r)
+}
+
+// requote requests quotes again for the route of an approval request and replaces the quote by the equivalent quote of
+// the same fleet and vehicle class. It returns whether the request has to be approved again
+func requote(a *util.AuthInfo, r *util.ApprovalRequest) (bool, error) {
+	quotesList, err := getQuotes(a, r.Origin, r.Destination, r.PickupTime)
+	if err != nil {
+		return false, err
+	}
+	retrievedQuoteList, err := retrieveQuoteList(a, quotesList.ID)
+	if err != nil {
+		return false, err
+	}
+	equivalent, err := util.FindEquivalentQuote(r.ApprovedQuote(), retrievedQuoteList.Quotes, approvalRequoteTolerance)
+	if err != nil {
+		return false, err
+	}
+	return r.Requote(*equivalent, util.QuoteExpiration(time.Now(), retrievedQuoteList.Validity)), nil
+}
+
+func runApprovals(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: karhoo approvals request|list|approve|reject [flags]")
+		return exitUsage
+	}
+	switch args[0] {
+	case "request":
+		fs := newFlagSet("approvals request")
+		quoteID := fs.String("quote", "", "ID of the quote to book (required)")
+		from := addLocationFlags(fs, "from")
+		to := addLocationFlags(fs, "to")
+		pickupTime := fs.String("pickup", "", "local pickup time the quote is for, re-quoting after it expired uses it too")
+		firstName := fs.String("first-name", "", "passenger first name (required)")
+		lastName := fs.String("last-name", "", "passenger last name (required)")
+		phone := fs.String("phone", "", "passenger phone number in E.164 format, e.g. +15005550006 (required)")
+		email := fs.String("email", "", "passenger email")
+		maxPrice := fs.Int("max-price", 0, "highest price booked without approval, in the smallest currency unit")
+		currency := fs.String("currency", "", "currency of -max-price, other currencies need approval")
+		classes := fs.String("classes", "", "comma separated vehicle classes booked without approval, all if empty")
+		output := outputFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		i := strings.Index(*quoteID, ":")
+		if i <= 0 {
+			return usageError(fs, "-quote must be the ID of a quote of a quotes list")
+		}
+		if from.empty() || to.empty() {
+			return usageError(fs, "origin and destination are required")
+		}
+		a, code := cliAuthInfo()
+		if code != exitOK {
+			return code
+		}
+		origin, err := from.geolocation(a)
+		if err != nil {
+			return fail(err)
+		}
+		destination, err := to.geolocation(a)
+		if err != nil {
+			return fail(err)
+		}
+		quotesList, err := retrieveQuoteList(a, (*quoteID)[:i])
+		if err != nil {
+			return fail(err)
+		}
+		bookingRequest, err := util.NewBookingRequestBuilderForQuoteID(*quoteID).
+			PassengerDetails(util.Passenger{FirstName: *firstName, LastName: *lastName, PhoneNumber: *phone, Email: *email}).
+			Build()
+		if err != nil {
+			return fail(err)
+		}
+		policy := util.TravelPolicy{MaxPrice: *maxPrice, CurrencyCode: *currency}
+		if *classes != "" {
+			policy.AllowedVehicleClasses = strings.Split(*classes, ",")
+		}
+		bookingDetails, r, err := requestBooking(a, "", policy, quotesList, *origin, *destination, *pickupTime, bookingRequest)
+		if err != nil {
+			return fail(err)
+		}
+		if r != nil {
+			return printOutput(*output, r, func(w *tabwriter.Writer) {
+				printApprovalTable(w, r)
+			})
+		}
+		return printOutput(*output, bookingDetails, func(w *tabwriter.Writer) {
+			printBookingTable(w, bookingDetails)
+		})
+	case "list":
+		fs := newFlagSet("approvals list")
+		output := outputFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		pending, err := listPendingApprovals()
+		if err != nil {
+			return fail(err)
+		}
+		return printOutput(*output, pending, func(w *tabwriter.Writer) {
+			fmt.Fprintln(w, "APPROVAL ID\tCREATED\tFLEET\tCLASS\tPRICE\tVIOLATIONS")
+			for _, r := range pending {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, formatLedgerDate(r.CreatedAt), r.FleetName, r.VehicleClass,
+					util.FormatPrice(r.Price, r.CurrencyCode), strings.Join(r.Violations, "; "))
+			}
+		})
+	case "approve", "reject":
+		fs := newFlagSet("approvals " + args[0])
+		approvalID := fs.String("id", "", "ID of the approval request (required)")
+		approver := fs.String("approver", "", "who decides on the request (required)")
+		reason := fs.String("reason", "", "why the request is rejected")
+		output := outputFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		if *approvalID == "" || *approver == "" {
+			return usageError(fs, "-id and -approver are required")
+		}
+		if args[0] == "reject" {
+			err := rejectBooking(*approvalID, *approver, *reason)
+			if err != nil {
+				return fail(err)
+			}
+			fmt.Printf("approval request %s rejected\n", *approvalID)
+			return exitOK
+		}
+		a, code := cliAuthInfo()
+		if code != exitOK {
+			return code
+		}
+		bookingDetails, err := approveBooking(a, *approvalID, *approver)
+		if err != nil {
+			return fail(err)
+		}
+		return printOutput(*output, bookingDetails, func(w *tabwriter.Writer) {
+			printBookingTable(w, bookingDetails)
+		})
+	}
+	fmt.Fprintf(os.Stderr, "unknown approvals command %q, use request, list, approve or reject\n", args[0])
+	return exitUsage
+}
+
+func printApprovalTable(w *tabwriter.Writer, r *util.ApprovalRequest) {
+	fmt.Fprintf(w, "APPROVAL ID\t%s\n", r.ID)
+	fmt.Fprintf(w, "STATUS\t%s\n", r.Status)
+	fmt.Fprintf(w, "QUOTE\t%s %s, %s\n", r.FleetName, r.VehicleClass, util.FormatPrice(r.Price, r.CurrencyCode))
+	for _, v := range r.Violations {
+		fmt.Fprintf(w, "VIOLATION\t%s\n", v)
+	}
+	fmt.Fprintf(w, "QUOTE EXPIRES\t%s\n", formatLedgerDate(r.ExpiresAt))
+}
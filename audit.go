@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"karhooAPIs.com/util"
+)
+
+// auditLogPath location of the audit log, KARHOO_AUDIT_LOG overrides the default next to the stored access token
+func auditLogPath() string {
+	if path := os.Getenv("KARHOO_AUDIT_LOG"); path != "" {
+		return path
+	}
+	return filepath.Join(util.DataDir(), ".karhoo-audit.log")
+}
+
+// openAuditLog opens the audit log, calls are recorded for the OS user unless the gateway or gRPC server names the
+// client they are made for
+func openAuditLog(path string) (*util.AuditLog, error) {
+	l, err := util.OpenAuditLog(path)
+	if err != nil {
+		return nil, err
+	}
+	l.Actor = "unknown"
+	if u, err := user.Current(); err == nil {
+		l.Actor = u.Username
+	}
+	return l, nil
+}
+
+func runAudit(args []string) int {
+	if len(args) == 0 || args[0] != "verify" {
+		fmt.Fprintln(os.Stderr, "usage: karhoo audit verify [flags]")
+		return exitUsage
+	}
+	fs := newFlagSet("audit verify")
+	path := fs.String("file", auditLogPath(), "audit log to verify")
+	lastHash := fs.String("hash", "", "hash the last entry must have, e.g. noted down by an earlier verification, detects entries cut off at the end")
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	f, err := os.Open(*path)
+	if err != nil {
+		return fail(err)
+	}
+	defer f.Close()
+	v, err := util.VerifyAuditLog(f)
+	var chainErr *util.AuditChainError
+	if errors.As(err, &chainErr) {
+		fmt.Fprintf(os.Stderr, "error: %v, %d entries before it are intact\n", chainErr, v.Entries)
+		return exitError
+	}
+	if err != nil {
+		return fail(err)
+	}
+	if *lastHash != "" && v.LastHash != *lastHash {
+		fmt.Fprintf(os.Stderr, "error: last entry (seq %d) has hash %s, expected %s\n", v.Entries, v.LastHash, *lastHash)
+		return exitError
+	}
+	fmt.Printf("%d entries intact, last hash %s\n", v.Entries, v.LastHash)
+	return exitOK
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// batchOptions limits of a batch quote run
+type batchOptions struct {
+	// Concurrency number of routes priced at the same time
+	Concurrency int
+	// RequestsPerSecond maximum karhoo calls per second across all routes, 0 means unlimited
+	RequestsPerSecond int
+	// PollInterval wait between two retrieveQuoteList calls while a quotes list is progressing
+	PollInterval time.Duration
+	// PollTimeout give up waiting for a quotes list to complete and use the quotes retrieved so far
+	PollTimeout time.Duration
+}
+
+// defaultBatchOptions batch options suitable for the sandbox
+var defaultBatchOptions = batchOptions{
+	Concurrency:       5,
+	RequestsPerSecond: 5,
+	PollInterval:      time.Second,
+	PollTimeout:       time.Second * 20,
+}
+
+// batchQuotes prices every route, results are in the same order as routes and carry a per route error
+func batchQuotes(a *util.AuthInfo, routes []util.RouteRequest, options batchOptions) []util.RouteQuotes {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 1
+	}
+	// the batch has its own budget on top of util.Limiter so that it leaves room for other callers
+	limiter := util.NewRateLimiter(util.NewMemoryRateLimitBackend(), &util.RateLimit{Rate: float64(options.RequestsPerSecond), Burst: 1})
+	wait := func() { limiter.Wait(context.Background(), "batch") }
+
+	results := make([]util.RouteQuotes, len(routes))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < options.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				start := time.Now()
+				quotesList, err := quoteRoute(a, routes[j], options, wait)
+				results[j] = util.RouteQuotes{Route: routes[j], QuotesList: quotesList, Err: err, Duration: time.Since(start)}
+			}
+		}()
+	}
+	for j := range routes {
+		jobs <- j
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func quoteRoute(a *util.AuthInfo, route util.RouteRequest, options batchOptions, wait func()) (*util.QuotesList, error) {
+	fresh, err := freshAuthInfo(a)
+	if err != nil {
+		return nil, err
+	}
+	wait()
+	quotesList, err := getQuotes(fresh, route.Origin, route.Destination, route.PickupTime)
+	if err != nil {
+		return nil, err
+	}
+	return pollQuoteList(fresh, quotesList.ID, options.PollInterval, options.PollTimeout, wait)
+}
+
+// pollQuoteList retrieves a quotes list until all fleets have quoted or timeout passes, wait is called before each call
+func pollQuoteList(a *util.AuthInfo, quoteListID string, interval, timeout time.Duration, wait func()) (*util.QuotesList, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		wait()
+		quotesList, err := retrieveQuoteList(a, quoteListID)
+		if err != nil {
+			return nil, err
+		}
+		if quotesList.Status != util.QuotesListProgressing {
+			return quotesList, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			if len(quotesList.Quotes) == 0 {
+				return nil, errors.New("no quotes received before timeout for quotes list " + quoteListID)
+			}
+			return quotesList, nil
+		}
+		time.Sleep(interval)
+	}
+}
+
+func runBatch(args []string) int {
+	fs := newFlagSet("batch")
+	routesFile := fs.String("routes", "", `json file with an array of routes, e.g. [{"id": "1", "origin": {"latitude": 50.037933, `+
+		`"longitude": 8.562152}, "destination": {...}, "pickup_time": "2021-01-08T10:30"}] (required)`)
+	format := fs.String("o", "csv", "output format: csv or json")
+	outFile := fs.String("out", "", "file to write the results to, stdout if empty")
+	concurrency := fs.Int("concurrency", defaultBatchOptions.Concurrency, "number of routes priced at the same time")
+	requestsPerSecond := fs.Int("rps", defaultBatchOptions.RequestsPerSecond, "maximum karhoo calls per second, 0 means unlimited")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *routesFile == "" {
+		return usageError(fs, "-routes is required")
+	}
+	write := util.WriteBatchQuotesCSV
+	switch *format {
+	case "csv":
+	case "json":
+		write = util.WriteBatchQuotesJSON
+	default:
+		return usageError(fs, "unknown output format %q, use csv or json", *format)
+	}
+	f, err := os.Open(*routesFile)
+	if err != nil {
+		return fail(err)
+	}
+	routes, err := util.ReadRouteRequests(f)
+	f.Close()
+	if err != nil {
+		return fail(fmt.Errorf("%s: %w", *routesFile, err))
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	options := defaultBatchOptions
+	options.Concurrency = *concurrency
+	options.RequestsPerSecond = *requestsPerSecond
+	results := batchQuotes(a, routes, options)
+
+	var out io.Writer = os.Stdout
+	if *outFile != "" {
+		file, err := os.Create(*outFile)
+		if err != nil {
+			return fail(err)
+		}
+		defer file.Close()
+		out = file
+	}
+	err = write(out, results)
+	if err != nil {
+		return fail(err)
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "warning: %d of %d routes could not be priced, see the error column\n", failed, len(routes))
+	}
+	if failed > 0 && failed == len(routes) {
+		return exitError
+	}
+	return exitOK
+}
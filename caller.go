@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+
+	"karhooAPIs.com/util"
+)
+
+// correlationIDHeader header, and gRPC metadata key, carrying the correlation ID of a request
+const correlationIDHeader = "X-Correlation-ID"
+
+// caller who a gateway or gRPC request is served for
+type caller struct {
+	client        string
+	correlationID string
+}
+
+// callerKey context key of the caller
+type callerKey struct{}
+
+func withCaller(ctx context.Context, c caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// callerClient API key client of the caller in ctx, empty if there is none
+func callerClient(ctx context.Context) string {
+	c, _ := ctx.Value(callerKey{}).(caller)
+	return c.client
+}
+
+// callerAuthInfo fresh copy of the shared auth info, with the client and correlation ID of the caller in ctx
+func callerAuthInfo(ctx context.Context, shared *util.AuthInfo) (*util.AuthInfo, error) {
+	a, err := freshAuthInfo(shared)
+	if err != nil {
+		return nil, err
+	}
+	c, _ := ctx.Value(callerKey{}).(caller)
+	a.Actor, a.CorrelationID = c.client, c.correlationID
+	return a, nil
+}
+
+// correlationID the correlation ID sent by a caller, or a new one if it sent none or an unusable one
+func correlationID(sent string) string {
+	if sent == "" || len(sent) > 128 {
+		return util.GenerateID()
+	}
+	for _, r := range sent {
+		if r <= ' ' || r > '~' {
+			return util.GenerateID()
+		}
+	}
+	return sent
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"karhooAPIs.com/util"
+)
+
+// exit codes of the CLI, scripts can tell why a command failed without parsing its output
+const (
+	exitOK = iota
+	// exitError any error not covered below, e.g. network errors
+	exitError
+	// exitUsage wrong subcommand, flags or arguments
+	exitUsage
+	// exitAuth no credentials, or logging in failed
+	exitAuth
+	// exitValidation request rejected before calling karhoo
+	exitValidation
+	// exitNotServiceable no fleet serves the route
+	exitNotServiceable
+	// exitAPIError karhoo rejected the request
+	exitAPIError
+	// exitUnavailable karhoo is failing and its circuit is open
+	exitUnavailable
+	// exitNotConfirmed the user did not confirm a cancellation fee
+	exitNotConfirmed
+)
+
+// command a CLI subcommand, run gets the arguments after the subcommand name and returns the exit code
+type command struct {
+	usage string
+	run   func(args []string) int
+}
+
+// commands all subcommands by name
+var commands = map[string]command{}
+
+func init() {
+	commands["login"] = command{"log in with the credentials file or -username/-password and store the access token", runLogin}
+	commands["quote"] = command{"get quotes for a route", runQuote}
+	commands["place"] = command{"look up the place of an address, or at -lat and -lng", runPlace}
+	commands["batch"] = command{"price a file of routes and export the quotes as csv or json", runBatch}
+	commands["book"] = command{"book a quote", runBook}
+	commands["status"] = command{"show the status of a booking, -watch keeps polling until the trip ends", runStatus}
+	commands["track"] = command{"follow the driver of a booking until the trip ends", runTrack}
+	commands["search"] = command{"search bookings at karhoo by -status, -date, -traveller, -cost-center or -organisation", runSearch}
+	commands["cancel"] = command{"cancel a booking", runCancel}
+	commands["fare"] = command{"compare the fare of a booking, or of the trips of a -date, to the quoted price", runFare}
+	commands["webhook"] = command{"register|list webhooks", runWebhook}
+	commands["wizard"] = command{"interactive booking wizard for support desk agents", runWizard}
+	commands["serve"] = command{"run the HTTP gateway giving frontends quotes, bookings and tracking by API key", runServe}
+	commands["grpc"] = command{"run the gRPC server for backend services", runGRPC}
+	commands["approvals"] = command{"request|list|approve|reject bookings outside the travel policy", runApprovals}
+	commands["ledger"] = command{"show recorded bookings by -booking, -traveller or -date", runLedger}
+	commands["audit"] = command{"verify the hash chain of the audit log of karhoo calls", runAudit}
+	commands["demo"] = command{"run the scripted demo booking and cancelling a ride from Frankfurt Airport", runDemo}
+}
+
+// runCLI dispatches to the subcommand named by the first argument
+func runCLI(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		printUsage(os.Stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		printUsage(os.Stderr)
+		return exitUsage
+	}
+	return cmd.run(args[1:])
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: karhoo <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", name, commands[name].usage)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `run "karhoo <command> -h" for the flags of a command`)
+}
+
+// newFlagSet creates the flag set of a subcommand, parse errors are reported by the caller with exitUsage
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("karhoo "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// outputFlag adds the -o flag choosing between table and json output
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("o", "table", "output format: table or json")
+}
+
+// printOutput prints v as indented json, or as a table written by table
+func printOutput(format string, v interface{}, table func(w *tabwriter.Writer)) int {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "	")
+		err := encoder.Encode(v)
+		if err != nil {
+			return fail(err)
+		}
+	case "table":
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		table(tw)
+		tw.Flush()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format %q, use table or json\n", format)
+		return exitUsage
+	}
+	return exitOK
+}
+
+// fail prints err and maps it to an exit code
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	var validationErrors util.ValidationErrors
+	var notServiceable *util.NotServiceableError
+	var circuitOpen *util.CircuitOpenError
+	var apiError *util.APIError
+	switch {
+	case errors.As(err, &validationErrors):
+		return exitValidation
+	case errors.As(err, &notServiceable):
+		return exitNotServiceable
+	case errors.As(err, &circuitOpen):
+		return exitUnavailable
+	case errors.Is(err, errCancellationNotConfirmed):
+		return exitNotConfirmed
+	case errors.As(err, &apiError):
+		if apiError.StatusCode == 401 || apiError.StatusCode == 403 {
+			return exitAuth
+		}
+		return exitAPIError
+	}
+	return exitError
+}
+
+// usageError prints a usage problem of a subcommand
+func usageError(fs *flag.FlagSet, format string, args ...interface{}) int {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	fs.Usage()
+	return exitUsage
+}
+
+// cliAuthInfo returns the access token stored by "karhoo login", refreshed if needed. Without a stored token it logs
+// in with the credentials file
+func cliAuthInfo() (*util.AuthInfo, int) {
+	a, err := util.LoadAuthInfo()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Fprintln(os.Stderr, "error: stored access token unreadable, run karhoo login:", err)
+			return nil, exitAuth
+		}
+		a, err = getAccessToken()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error: login failed:", err)
+			return nil, exitAuth
+		}
+	}
+	// ties the log events of this command together
+	a.CorrelationID = util.GenerateID()
+	err = refreshAccessTokenIfExpired(a)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: refreshing access token failed, run karhoo login:", err)
+		return nil, exitAuth
+	}
+	// keep the refreshed token for the next command, a failure only costs a refresh next time
+	err = util.SaveAuthInfo(a)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "warning: storing the access token failed, it is refreshed again next time:", err)
+	}
+	return a, exitOK
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+func runLogin(args []string) int {
+	fs := newFlagSet("login")
+	username := fs.String("username", "", "karhoo username, defaults to the credentials file")
+	password := fs.String("password", "", "karhoo password, defaults to the credentials file")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	cred := &util.Credentials{Username: *username, Password: *password}
+	if cred.Username == "" || cred.Password == "" {
+		var err error
+		cred, err = util.RetrieveCredentials()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error: no credentials:", err)
+			return exitAuth
+		}
+	}
+	a, err := getAccessTokenWithCredentials(cred)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: login failed:", err)
+		return exitAuth
+	}
+	err = util.SaveAuthInfo(a)
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Printf("logged in as %s, access token expires at %s\n", cred.Username, a.ExpirationTime.Format(time.RFC3339))
+	return exitOK
+}
+
+// locationFlags flags describing a place either by address or by coordinates
+type locationFlags struct {
+	address   *string
+	latitude  *float64
+	longitude *float64
+}
+
+func addLocationFlags(fs *flag.FlagSet, name string) locationFlags {
+	return locationFlags{
+		address:   fs.String(name, "", name+" address, looked up unless -"+name+"-lat and -"+name+"-lng are given"),
+		latitude:  fs.Float64(name+"-lat", 0, name+" latitude"),
+		longitude: fs.Float64(name+"-lng", 0, name+" longitude"),
+	}
+}
+
+func (l locationFlags) geolocation(a *util.AuthInfo) (*util.Geolocation, error) {
+	if *l.latitude != 0 || *l.longitude != 0 {
+		position, err := util.NewPosition(*l.latitude, *l.longitude)
+		if err != nil {
+			return nil, err
+		}
+		return &util.Geolocation{Position: position, DisplayAddress: *l.address}, nil
+	}
+	return lookupGeolocation(a, *l.address, nil)
+}
+
+func (l locationFlags) empty() bool {
+	return *l.address == "" && *l.latitude == 0 && *l.longitude == 0
+}
+
+func runQuote(args []string) int {
+	fs := newFlagSet("quote")
+	from := addLocationFlags(fs, "from")
+	to := addLocationFlags(fs, "to")
+	pickupTime := fs.String("pickup", "", "local pickup time, e.g. 2021-01-08T10:30, immediate pickup if empty")
+	class := fs.String("class", "", "only show quotes of this vehicle class")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if from.empty() || to.empty() {
+		return usageError(fs, "origin and destination are required")
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	origin, err := from.geolocation(a)
+	if err != nil {
+		return fail(err)
+	}
+	destination, err := to.geolocation(a)
+	if err != nil {
+		return fail(err)
+	}
+	err = checkRouteCoverage(a, *origin, *destination, *pickupTime)
+	if err != nil {
+		return fail(err)
+	}
+	quotesList, err := getQuotesCached(a, *origin, *destination, *pickupTime)
+	if err != nil {
+		return fail(err)
+	}
+	quotes := []util.Quote{}
+	for _, quote := range quotesList.Quotes {
+		if *class == "" || strings.EqualFold(quote.Vehicle.Class, *class) {
+			quotes = append(quotes, quote)
+		}
+	}
+	return printOutput(*output, quotes, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "QUOTE ID\tFLEET\tCLASS\tTYPE\tPASSENGERS\tPRICE\tQTA")
+		for _, q := range quotes {
+			price := util.FormatPrice(q.Price.Low, q.Price.CurrencyCode)
+			if q.Price.High != q.Price.Low {
+				price += " - " + util.FormatPrice(q.Price.High, q.Price.CurrencyCode)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%d-%d min\n", q.ID, q.Fleet.Name, q.Vehicle.Class, q.QuoteType,
+				q.Vehicle.PassengerCapacity, price, q.Vehicle.QTA.LowMinutes, q.Vehicle.QTA.HighMinutes)
+		}
+	})
+}
+
+func runBook(args []string) int {
+	fs := newFlagSet("book")
+	quoteID := fs.String("quote", "", "ID of the quote to book (required)")
+	firstName := fs.String("first-name", "", "passenger first name (required)")
+	lastName := fs.String("last-name", "", "passenger last name (required)")
+	phone := fs.String("phone", "", "passenger phone number in E.164 format, e.g. +15005550006 (required)")
+	email := fs.String("email", "", "passenger email")
+	additionalPassengers := fs.Int("additional-passengers", 0, "number of passengers travelling without contact details")
+	luggage := fs.Int("luggage", 0, "total number of luggage")
+	flightNumber := fs.String("flight", "", "flight number of an airport pickup")
+	trainNumber := fs.String("train", "", "train number of a station pickup")
+	comments := fs.String("comments", "", "comments for the driver")
+	partnerTripID := fs.String("partner-trip-id", "", "our own trip ID")
+	costCenter := fs.String("cost-center", "", "cost center reference")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *quoteID == "" {
+		return usageError(fs, "-quote is required")
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	builder := util.NewBookingRequestBuilderForQuoteID(*quoteID)
+	// quote IDs start with the ID of their quotes list, with the quote at hand capacity and capabilities are checked too
+	quote, err := findQuote(a, *quoteID)
+	if err != nil {
+		return fail(err)
+	}
+	if quote != nil {
+		builder = util.NewBookingRequestBuilder(*quote)
+	}
+	builder.PassengerDetails(util.Passenger{FirstName: *firstName, LastName: *lastName, PhoneNumber: *phone, Email: *email}).
+		AdditionalPassengers(*additionalPassengers).
+		Luggage(*luggage).
+		Comments(*comments).
+		PartnerTripID(*partnerTripID).
+		CostCenter(*costCenter)
+	if *flightNumber != "" {
+		builder.FlightNumber(*flightNumber)
+	}
+	if *trainNumber != "" {
+		builder.TrainNumber(*trainNumber)
+	}
+	bookingRequest, err := builder.Build()
+	if err != nil {
+		return fail(err)
+	}
+	bookingDetails, err := bookATrip(a, bookingRequest)
+	if err != nil {
+		return fail(err)
+	}
+	if quote != nil {
+		recordQuoteSelection(*quote)
+	}
+	return printOutput(*output, bookingDetails, func(w *tabwriter.Writer) {
+		printBookingTable(w, bookingDetails)
+	})
+}
+
+// findQuote looks up a quote in its quotes list, nil if the quote ID names no quotes list or the list does not have
+// the quote. Failing to retrieve the quotes list is an error, the booking can not be checked against the quote
+func findQuote(a *util.AuthInfo, quoteID string) (*util.Quote, error) {
+	i := strings.Index(quoteID, ":")
+	if i <= 0 {
+		return nil, nil
+	}
+	quotesList, err := retrieveQuoteList(a, quoteID[:i])
+	if err != nil {
+		return nil, fmt.Errorf("looking up quote %s: %w", quoteID, err)
+	}
+	for _, quote := range quotesList.Quotes {
+		if quote.ID == quoteID {
+			return &quote, nil
+		}
+	}
+	return nil, nil
+}
+
+func printBookingTable(w *tabwriter.Writer, d *util.BookingDetails) {
+	fmt.Fprintf(w, "BOOKING ID\t%s\n", d.ID)
+	fmt.Fprintf(w, "TRIP ID\t%s\n", d.DisplayTripID)
+	fmt.Fprintf(w, "STATUS\t%s\n", d.Status)
+	fmt.Fprintf(w, "FROM\t%s\n", d.Origin.DisplayAddress)
+	fmt.Fprintf(w, "TO\t%s\n", d.Destination.DisplayAddress)
+	if !d.DateScheduled.IsZero() {
+		fmt.Fprintf(w, "SCHEDULED\t%s\n", d.DateScheduled.Format(time.RFC3339))
+	}
+	if d.FleetInfo.Name != "" {
+		fmt.Fprintf(w, "FLEET\t%s\n", d.FleetInfo.Name)
+	}
+	if d.Vehicle.Driver.FirstName != "" {
+		fmt.Fprintf(w, "DRIVER\t%s %s\n", d.Vehicle.Driver.FirstName, d.Vehicle.Driver.LastName)
+	}
+	if d.Vehicle.VehicleLicensePlate != "" {
+		fmt.Fprintf(w, "VEHICLE\t%s (%s)\n", d.Vehicle.Description, d.Vehicle.VehicleLicensePlate)
+	}
+	if d.Fare.Total > 0 {
+		fmt.Fprintf(w, "FARE\t%s\n", util.FormatPrice(d.Fare.Total, d.Fare.Currency))
+	} else if d.Quote.HighPrice > 0 {
+		fmt.Fprintf(w, "QUOTED\t%s - %s\n", util.FormatPrice(d.Quote.LowPrice, d.Quote.Currency), util.FormatPrice(d.Quote.HighPrice, d.Quote.Currency))
+	}
+	if d.FollowCode != "" {
+		fmt.Fprintf(w, "FOLLOW CODE\t%s\n", d.FollowCode)
+	}
+}
+
+func runStatus(args []string) int {
+	fs := newFlagSet("status")
+	watch := fs.Bool("watch", false, "keep polling and print changes until the trip ends")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "usage: karhoo status [flags] <booking-id>")
+	}
+	bookingID := fs.Arg(0)
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	if !*watch {
+		bookingDetails, err := getBookingDetails(a, bookingID)
+		if err != nil {
+			return fail(err)
+		}
+		return printOutput(*output, bookingDetails, func(w *tabwriter.Writer) {
+			printBookingTable(w, bookingDetails)
+		})
+	}
+
+	w := newBookingWatcher(a, util.DefaultPollIntervals)
+	w.Watch(bookingID)
+	code = exitOK
+	for event := range w.Events() {
+		if event.Err != nil {
+			// karhoo being unreachable or failing passes, a wrong booking ID or missing access does not. Events with
+			// details report an unexpected status change
+			if event.Details == nil && !transientPollError(event.Err) {
+				w.Stop()
+				return fail(event.Err)
+			}
+			fmt.Fprintln(os.Stderr, "error:", event.Err)
+			continue
+		}
+		code = printOutput(*output, event, func(tw *tabwriter.Writer) {
+			fmt.Fprintf(tw, "%s\t%s\n", time.Now().Format(time.RFC3339), event.Details.Status)
+			for _, change := range event.Changes {
+				fmt.Fprintf(tw, "\t%s\t%s -> %s\n", change.Field, change.Old, change.New)
+			}
+		})
+		if event.Details.Status.IsTerminal() {
+			// the watcher stopped polling the booking, stopping it closes the events channel
+			go w.Stop()
+		}
+	}
+	return code
+}
+
+func runCancel(args []string) int {
+	fs := newFlagSet("cancel")
+	reason := fs.String("reason", string(util.CancelOtherUserReason), "cancel reason, one of "+joinCancelReasons())
+	yes := fs.Bool("yes", false, "accept the cancellation fee if one applies")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "usage: karhoo cancel [flags] <booking-id>")
+	}
+	cancelReason, err := util.ParseCancelReason(*reason)
+	if err != nil {
+		return usageError(fs, err.Error())
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	bookingID := fs.Arg(0)
+	err = cancelBookingWithFeeCheck(a, bookingID, cancelReason, func(fee *util.CancellationFee) bool {
+		fmt.Fprintln(os.Stderr, fee)
+		if !*yes {
+			fmt.Fprintln(os.Stderr, "run again with -yes to cancel anyway")
+		}
+		return *yes
+	})
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Printf("booking %s cancelled\n", bookingID)
+	return exitOK
+}
+
+func joinCancelReasons() string {
+	reasons := make([]string, len(util.CancelBookingReasons))
+	for i, r := range util.CancelBookingReasons {
+		reasons[i] = string(r)
+	}
+	return strings.Join(reasons, ", ")
+}
+
+func runWebhook(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: karhoo webhook register|list [flags]")
+		return exitUsage
+	}
+	switch args[0] {
+	case "register":
+		fs := newFlagSet("webhook register")
+		url := fs.String("url", "", "url karhoo posts booking events to (required)")
+		secret := fs.String("secret", util.WebhookSecretKey, "shared secret used to sign webhook requests")
+		if err := fs.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		if *url == "" {
+			return usageError(fs, "-url is required")
+		}
+		a, code := cliAuthInfo()
+		if code != exitOK {
+			return code
+		}
+		err := registerWebhook(a, *url, *secret)
+		if err != nil {
+			return fail(err)
+		}
+		fmt.Printf("webhook %s registered\n", *url)
+		return exitOK
+	case "list":
+		fs := newFlagSet("webhook list")
+		output := outputFlag(fs)
+		if err := fs.Parse(args[1:]); err != nil {
+			return exitUsage
+		}
+		a, code := cliAuthInfo()
+		if code != exitOK {
+			return code
+		}
+		subscription, err := getRegisteredWebhookURLs(a)
+		if err != nil {
+			return fail(err)
+		}
+		return printOutput(*output, subscription, func(w *tabwriter.Writer) {
+			fmt.Fprintln(w, "URL")
+			fmt.Fprintln(w, subscription.URL)
+		})
+	}
+	fmt.Fprintf(os.Stderr, "unknown webhook command %q, use register or list\n", args[0])
+	return exitUsage
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// coverageCache coverage rarely changes, positions within about 100 meters share a cache entry for an hour
+var coverageCache = util.NewCoverageCache(time.Hour, 3)
+
+// checkRouteCoverage returns a *util.NotServiceableError if no fleet serves the origin or the destination, so that
+// callers can tell the user up front instead of getting an empty quote list
+func checkRouteCoverage(a *util.AuthInfo, origin, destination util.Geolocation, pickupTime string) error {
+	originCovered, err := isCovered(a, origin.Position, pickupTime)
+	if err != nil {
+		return err
+	}
+	destinationCovered, err := isCovered(a, destination.Position, pickupTime)
+	if err != nil {
+		return err
+	}
+	if originCovered && destinationCovered {
+		return nil
+	}
+	notServiceable := &util.NotServiceableError{}
+	if !originCovered {
+		notServiceable.Origin = &origin
+	}
+	if !destinationCovered {
+		notServiceable.Destination = &destination
+	}
+	return notServiceable
+}
+
+// isCovered checks the coverage of a position, cached for immediate pickups only since fleets' hours vary
+func isCovered(a *util.AuthInfo, position util.Position, pickupTime string) (bool, error) {
+	now := time.Now()
+	if pickupTime == "" {
+		if covered, ok := coverageCache.Get(position, now); ok {
+			return covered, nil
+		}
+	}
+	covered, err := checkCoverage(a, position, pickupTime)
+	if err != nil {
+		return false, err
+	}
+	if pickupTime == "" {
+		coverageCache.Set(position, covered, now)
+	}
+	return covered, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+
+	"karhooAPIs.com/util"
+)
+
+// runDemo the scripted walk through of the karhoo api: quote a fixed Frankfurt route, book, look up and cancel
+func runDemo(args []string) int {
+	bookingStates.OnTransition(func(t util.BookingTransition) {
+		util.LogEvent(util.LevelInfo, "booking status changed", util.LogField("booking_id", t.BookingID),
+			util.LogField("from", t.From), util.LogField("to", t.To))
+	})
+	// ****************************** get access token
+	authInfo, err := getAccessToken()
+	if err != nil {
+		return fail(err)
+	}
+	authInfo.CorrelationID = util.GenerateID()
+	// ****************************** refresh access token if necessary
+	err = refreshAccessTokenIfExpired(authInfo)
+	if err != nil {
+		return fail(err)
+	}
+	// ****************************** register karhoo webhook
+	err = registerWebhook(authInfo, "http://karhoo-webhooks.piizu.com/webhook", util.WebhookSecretKey)
+	if err != nil {
+		return fail(err)
+	}
+
+	subscriptions, err := getRegisteredWebhookURLs(authInfo)
+	if err != nil {
+		return fail(err)
+	}
+	util.LogEvent(util.LevelInfo, "registered webhook", append(authInfo.LogFields(), util.LogField("subscription", subscriptions))...)
+	// ****************************** request quotes
+	origin := util.Geolocation{
+		Position:       util.Position{Latitude: 50.037933, Longitude: 8.562152},
+		DisplayAddress: "Frankfurt Airport",
+	}
+	destination := util.Geolocation{
+		Position:       util.Position{Latitude: 51.037933, Longitude: 8.910231},
+		DisplayAddress: "Some place nearby",
+	}
+	// ****************************** check that fleets serve the route before requesting quotes
+	err = checkRouteCoverage(authInfo, origin, destination, "")
+	if err != nil {
+		return fail(err)
+	}
+	quotesList, err := getQuotes(authInfo, origin, destination, "")
+	if err != nil {
+		return fail(err)
+	}
+	// ****************************** retrieve quote list
+	retrievedQuoteList, err := retrieveQuoteList(authInfo, quotesList.ID)
+	if err != nil {
+		return fail(err)
+	}
+	if len(retrievedQuoteList.Quotes) == 0 {
+		return fail(errors.New("failed to request quotes"))
+	}
+	util.LogEvent(util.LevelInfo, "retrieved quote list", append(authInfo.LogFields(), util.LogField("quotes_list", retrievedQuoteList))...)
+	// ****************************** aggregate quotes, select the lowest price for quotes with the same vehicle.class
+	vehicleClasses := retrievedQuoteList.Availability.Vehicles.Classes
+	if vehicleClasses == nil {
+		return fail(errors.New("empty vehicle classes"))
+	}
+	type quotePrice struct {
+		QuoteID     string
+		LowestPrice int
+	}
+	lowestPriceQuotes := map[string]quotePrice{}
+	for _, vehicleClass := range vehicleClasses {
+		lowestPriceQuotes[vehicleClass] = quotePrice{
+			QuoteID:     "",
+			LowestPrice: -1,
+		}
+	}
+	for _, quote := range retrievedQuoteList.Quotes {
+		if lowestPriceQuotes[quote.Vehicle.Class].LowestPrice == -1 ||
+			lowestPriceQuotes[quote.Vehicle.Class].LowestPrice > quote.Price.Low {
+			lowestPriceQuotes[quote.Vehicle.Class] = quotePrice{
+				QuoteID:     quote.ID,
+				LowestPrice: quote.Price.Low,
+			}
+		}
+	}
+	util.LogEvent(util.LevelInfo, "lowest price quotes", append(authInfo.LogFields(), util.LogField("quotes", lowestPriceQuotes))...)
+	// ****************************** select the lowest price quote with some vehicle.class and make a booking
+	vehicleClass := ""
+	quoteIDToBook := ""
+	for k, v := range lowestPriceQuotes {
+		if v.LowestPrice != -1 && v.QuoteID != "" {
+			// choose the first quote with lowest price
+			quoteIDToBook = v.QuoteID
+			vehicleClass = k
+			break
+		}
+	}
+	util.LogEvent(util.LevelInfo, "booking", append(authInfo.LogFields(), util.LogField("vehicle_class", vehicleClass),
+		util.LogField("quote_id", quoteIDToBook))...)
+	var quoteToBook util.Quote
+	for _, quote := range retrievedQuoteList.Quotes {
+		if quote.ID == quoteIDToBook {
+			quoteToBook = quote
+			break
+		}
+	}
+	bookingRequestBuilder := util.NewBookingRequestBuilder(quoteToBook).
+		Passenger("Chuoxian", "Yang", "+15005550006").
+		Luggage(1)
+	// origin is Frankfurt Airport, let the fleet follow the flight if it can
+	if quoteToBook.HasCapability(util.CapabilityFlightTracking) {
+		bookingRequestBuilder.FlightNumber("LH400")
+	}
+	bookingRequest, err := bookingRequestBuilder.Build()
+	if err != nil {
+		return fail(err)
+	}
+	bookingResults, err := bookATrip(authInfo, bookingRequest)
+	if err != nil {
+		return fail(err)
+	}
+	recordQuoteSelection(quoteToBook)
+	util.LogEvent(util.LevelInfo, "requested a booking", append(authInfo.LogFields(), util.LogField("booking", bookingResults))...)
+	_, err = bookingStates.Observe(bookingResults.ID, bookingResults.Status)
+	if err != nil {
+		return fail(err)
+	}
+	// ****************************** get booking details of previous book request
+	bookingDetails, err := getBookingDetails(authInfo, bookingResults.ID)
+	if err != nil {
+		return fail(err)
+	}
+	util.LogEvent(util.LevelInfo, "got booking details", append(authInfo.LogFields(), util.LogField("booking", bookingDetails))...)
+	_, err = bookingStates.Observe(bookingDetails.ID, bookingDetails.Status)
+	if err != nil {
+		return fail(err)
+	}
+	// ****************************** cancel booking
+	err = cancelBookingWithFeeCheck(authInfo, bookingDetails.ID, util.CancelOtherUserReason, func(fee *util.CancellationFee) bool {
+		util.LogEvent(util.LevelInfo, "cancelling anyway", append(authInfo.LogFields(), util.LogField("fee", fee))...)
+		return true
+	})
+	if err != nil {
+		return fail(err)
+	}
+	util.LogEvent(util.LevelInfo, "booking cancelled", append(authInfo.LogFields(), util.LogField("booking_id", bookingDetails.ID))...)
+	return exitOK
+}
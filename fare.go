@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// populateFare retrieves the final fare of a booking and stores it in bookingDetails.Fare. It returns false and leaves
+// the fare unset while the fare is still pending
+func populateFare(a *util.AuthInfo, bookingDetails *util.BookingDetails) (bool, error) {
+	fare, err := getFare(a, bookingDetails.ID)
+	if err != nil {
+		return false, err
+	}
+	if !fare.Final() {
+		return false, nil
+	}
+	bookingDetails.Fare = fare.Breakdown
+	return true, nil
+}
+
+// reconcileFares retrieves the fares of all completed bookings matching search and compares them to their quotes.
+// With onlyMetered set, fixed price trips are skipped since their fare can not differ from the quote. Trips whose fare
+// is still pending are listed as pending instead of being reconciled
+func reconcileFares(a *util.AuthInfo, search util.BookingSearch, tolerance util.FareTolerance, onlyMetered bool) (*util.ReconciliationReport, error) {
+	search.Statuses = []util.BookingStatus{util.BookingCompleted}
+	bookings := []util.BookingDetails{}
+	pending := []string{}
+	it := newBookingIterator(a, search)
+	for it.Next() {
+		bookingDetails := *it.Booking()
+		if onlyMetered && bookingDetails.Quote.Type != util.QuoteTypeMetered {
+			continue
+		}
+		final, err := populateFare(a, &bookingDetails)
+		if err != nil {
+			return nil, err
+		}
+		if !final {
+			pending = append(pending, bookingDetails.ID)
+			continue
+		}
+		bookings = append(bookings, bookingDetails)
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	return util.NewReconciliationReport(bookings, pending, tolerance), nil
+}
+
+func runFare(args []string) int {
+	fs := newFlagSet("fare")
+	date := fs.String("date", "", "reconcile the completed bookings with a pickup on this day, e.g. 2021-01-08, instead of one booking")
+	onlyMetered := fs.Bool("metered", false, "with -date, skip fixed price trips")
+	percent := fs.Float64("tolerance-percent", 10, "percent of the highest quoted price a fare may exceed it by")
+	amount := fs.Int("tolerance-amount", 0, "amount in the smallest currency unit a fare may exceed the highest quoted price by")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if !(*date == "" && fs.NArg() == 1) && !(*date != "" && fs.NArg() == 0) {
+		return usageError(fs, "usage: karhoo fare [flags] <booking-id>, or karhoo fare -date 2021-01-08 [flags]")
+	}
+	tolerance := util.FareTolerance{Amount: *amount, Percent: *percent}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+
+	if *date == "" {
+		bookingDetails, err := getBookingDetails(a, fs.Arg(0))
+		if err != nil {
+			return fail(err)
+		}
+		final, err := populateFare(a, bookingDetails)
+		if err != nil {
+			return fail(err)
+		}
+		if !final {
+			fmt.Fprintf(os.Stderr, "error: the fare of booking %s is still pending\n", bookingDetails.ID)
+			return exitError
+		}
+		r := util.ReconcileFare(bookingDetails, tolerance)
+		return printOutput(*output, r, func(w *tabwriter.Writer) {
+			printFareReconciliation(w, r)
+			for _, item := range r.Breakdown {
+				fmt.Fprintf(w, "\t%s\t%d -> %d\n", item.Name, item.Quoted, item.Charged)
+			}
+		})
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+	if err != nil {
+		return usageError(fs, "-date must look like 2021-01-08")
+	}
+	var search util.BookingSearch
+	search.ScheduledOn(day)
+	report, err := reconcileFares(a, search, tolerance, *onlyMetered)
+	if err != nil {
+		return fail(err)
+	}
+	return printOutput(*output, report, func(w *tabwriter.Writer) {
+		for _, r := range report.Trips {
+			printFareReconciliation(w, r)
+		}
+		for _, bookingID := range report.Pending {
+			fmt.Fprintf(w, "%s\tfare pending\n", bookingID)
+		}
+		currencies := []string{}
+		for currency := range report.TotalExcess {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			fmt.Fprintf(w, "TOTAL EXCESS\t%s\n", util.FormatPrice(report.TotalExcess[currency], currency))
+		}
+	})
+}
+
+func printFareReconciliation(w *tabwriter.Writer, r util.FareReconciliation) {
+	flag := ""
+	switch {
+	case r.CurrencyMismatch:
+		flag = "FLAGGED: charged in " + r.Currency + ", quoted in " + r.QuoteCurrency
+	case r.Flagged:
+		flag = "FLAGGED: above " + util.FormatPrice(r.Allowed, r.Currency)
+	}
+	fmt.Fprintf(w, "%s\t%s\tquoted %s - %s\tcharged %s\t%s\n", r.BookingID, r.QuoteType,
+		util.FormatPrice(r.LowPrice, r.QuoteCurrency), util.FormatPrice(r.HighPrice, r.QuoteCurrency),
+		util.FormatPrice(r.Fare, r.Currency), flag)
+}
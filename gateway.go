@@ -0,0 +1,533 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// maxGatewayRequestBody largest request body the gateway reads
+const maxGatewayRequestBody = 1 << 20
+
+// gateway HTTP service for frontends that must not hold karhoo credentials. It logs in to karhoo itself, refreshes the
+// token server side and authenticates its own clients by API key
+type gateway struct {
+	auth *util.AuthInfo
+	keys *util.APIKeys
+}
+
+// gatewayQuoteRequest request body of POST /v1/quotes
+type gatewayQuoteRequest struct {
+	Origin      *util.Geolocation `json:"origin"`
+	Destination *util.Geolocation `json:"destination"`
+	// PickupTime local pickup time, e.g. 2021-01-08T10:30, immediate pickup if empty
+	PickupTime string `json:"pickup_time"`
+}
+
+// gatewayApprovalRequest request body of POST /v1/approvals, the route and pickup time the quote is for are needed
+// to re-quote once the quote expired
+type gatewayApprovalRequest struct {
+	gatewayQuoteRequest
+	Policy  util.TravelPolicy    `json:"policy"`
+	Booking *util.BookingRequest `json:"booking"`
+}
+
+// gatewayDecisionRequest request body of POST /v1/approvals/{id}/approve and /reject
+type gatewayDecisionRequest struct {
+	Approver string `json:"approver"`
+	// Reason why the booking is rejected
+	Reason string `json:"reason"`
+}
+
+// gatewayCancelRequest request body of POST /v1/bookings/{id}/cancel
+type gatewayCancelRequest struct {
+	Reason util.CancelReason `json:"reason"`
+	// AcceptFee cancel even if a cancellation fee applies
+	AcceptFee bool `json:"accept_fee"`
+}
+
+func runServe(args []string) int {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", "address to listen on")
+	keysFile := fs.String("keys", "", `file with the API keys of the frontends, one "<client> <key>" per line (required)`)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *keysFile == "" {
+		return usageError(fs, "-keys is required")
+	}
+	f, err := os.Open(*keysFile)
+	if err != nil {
+		return fail(err)
+	}
+	keys, err := util.ParseAPIKeys(f)
+	f.Close()
+	if err != nil {
+		return fail(fmt.Errorf("%s: %w", *keysFile, err))
+	}
+	if keys.Len() == 0 {
+		fmt.Fprintf(os.Stderr, "error: no API keys in %s, the gateway would reject every request\n", *keysFile)
+		return exitUsage
+	}
+	if bookingLedger == nil {
+		return fail(errLedgerRequired)
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+
+	server := &http.Server{
+		Addr:         *addr,
+		Handler:      newGateway(a, keys).handler(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 2 * time.Minute,
+		IdleTimeout:  time.Minute,
+	}
+	done := make(chan struct{})
+	go func() {
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		<-interrupt
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+		close(done)
+	}()
+	util.LogEvent(util.LevelInfo, "gateway listening", util.LogField("addr", *addr), util.LogField("api_keys", keys.Len()))
+	err = server.ListenAndServe()
+	if err != http.ErrServerClosed {
+		return fail(err)
+	}
+	<-done
+	return exitOK
+}
+
+// newGateway creates a gateway calling karhoo with the shared auth info
+func newGateway(a *util.AuthInfo, keys *util.APIKeys) *gateway {
+	return &gateway{auth: a, keys: keys}
+}
+
+// handler routes the gateway's endpoints, all but /health require an API key
+func (g *gateway) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", g.health)
+	mux.Handle("/v1/quotes", g.authenticated(g.quotes))
+	mux.Handle("/v1/quotes/", g.authenticated(g.quotes))
+	mux.Handle("/v1/bookings", g.authenticated(g.bookings))
+	mux.Handle("/v1/bookings/", g.authenticated(g.bookings))
+	mux.Handle("/v1/approvals", g.authenticated(g.approvals))
+	mux.Handle("/v1/approvals/", g.authenticated(g.approvals))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeGatewayError(w, util.NewGatewayError(http.StatusNotFound, util.GatewayErrorNotFound, "no such endpoint"))
+	})
+	return mux
+}
+
+// authenticated checks the API key in the X-API-Key header, or as bearer token, and logs the request
+func (g *gateway) authenticated(handle func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := correlationID(r.Header.Get(correlationIDHeader))
+		w.Header().Set(correlationIDHeader, id)
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+		client, ok := g.keys.Client(key)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="karhoo gateway"`)
+			writeGatewayError(w, util.NewGatewayError(http.StatusUnauthorized, util.GatewayErrorUnauthorized, "missing or unknown API key"))
+			util.LogEvent(util.LevelWarn, "gateway request rejected", util.LogField("correlation_id", id),
+				util.LogField("method", r.Method), util.LogField("path", r.URL.Path), util.LogField("remote_addr", r.RemoteAddr))
+			return
+		}
+		fields := []util.Field{util.LogField("correlation_id", id), util.LogField("actor", client),
+			util.LogField("method", r.Method), util.LogField("path", r.URL.Path)}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		err := handle(recorder, r.WithContext(withCaller(r.Context(), caller{client: client, correlationID: id})))
+		if err != nil {
+			e := util.GatewayErrorFrom(err)
+			if e.StatusCode == http.StatusInternalServerError {
+				util.LogEvent(util.LevelError, "gateway request failed", append(fields, util.LogField("error", err))...)
+			}
+			writeGatewayError(recorder, e)
+		}
+		util.LogEvent(util.LevelInfo, "gateway request", append(fields, util.LogField("status", recorder.status),
+			util.LogField("latency_ms", time.Since(start).Milliseconds()))...)
+	})
+}
+
+func (g *gateway) health(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	if !circuitBreaker.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	writeGatewayJSON(w, status, map[string]interface{}{"circuits": circuitBreaker.States()})
+}
+
+// quotes POST /v1/quotes requests the quotes of a route, GET /v1/quotes/{id} retrieves a quotes list again
+func (g *gateway) quotes(w http.ResponseWriter, r *http.Request) error {
+	id, sub := splitGatewayPath(r.URL.Path, "/v1/quotes")
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req gatewayQuoteRequest
+		err := decodeGatewayRequest(w, r, &req)
+		if err != nil {
+			return err
+		}
+		err = req.validate()
+		if err != nil {
+			return err
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		err = checkRouteCoverage(a, *req.Origin, *req.Destination, req.PickupTime)
+		if err != nil {
+			return err
+		}
+		quotesList, err := getQuotesCached(a, *req.Origin, *req.Destination, req.PickupTime)
+		if err != nil {
+			return err
+		}
+		writeGatewayJSON(w, http.StatusOK, quotesList)
+		return nil
+	case id != "" && sub == "" && r.Method == http.MethodGet:
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		quotesList, err := retrieveQuoteList(a, id)
+		if err != nil {
+			return err
+		}
+		writeGatewayJSON(w, http.StatusOK, quotesList)
+		return nil
+	case id == "" || sub == "":
+		return errMethodNotAllowed
+	}
+	return errNoSuchEndpoint
+}
+
+// bookings POST /v1/bookings books a quote, GET /v1/bookings/{id} gets the status of a booking,
+// POST /v1/bookings/{id}/cancel cancels it and GET /v1/bookings/{id}/tracking tracks its driver
+func (g *gateway) bookings(w http.ResponseWriter, r *http.Request) error {
+	id, sub := splitGatewayPath(r.URL.Path, "/v1/bookings")
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var bookingRequest util.BookingRequest
+		err := decodeGatewayRequest(w, r, &bookingRequest)
+		if err != nil {
+			return err
+		}
+		err = bookingRequest.Validate()
+		if err != nil {
+			return err
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		// with the quote at hand capacity and capabilities are checked before karhoo sees the booking
+		quote, err := findQuote(a, bookingRequest.QuoteID)
+		if err != nil {
+			return err
+		}
+		if quote != nil {
+			err = bookingRequest.ValidateForQuote(*quote)
+			if err != nil {
+				return err
+			}
+		}
+		bookingDetails, err := bookATrip(a, &bookingRequest)
+		if err != nil {
+			return err
+		}
+		recordBookingOwner(r.Context(), bookingDetails.ID)
+		if quote != nil {
+			recordQuoteSelection(*quote)
+		}
+		writeGatewayJSON(w, http.StatusCreated, bookingDetails)
+		return nil
+	case id != "" && sub == "" && r.Method == http.MethodGet:
+		err := checkBookingOwner(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		bookingDetails, err := getBookingDetails(a, id)
+		if err != nil {
+			return err
+		}
+		writeGatewayJSON(w, http.StatusOK, bookingDetails)
+		return nil
+	case id != "" && sub == "cancel" && r.Method == http.MethodPost:
+		err := checkBookingOwner(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		var req gatewayCancelRequest
+		err = decodeGatewayRequest(w, r, &req)
+		if err != nil {
+			return err
+		}
+		if !req.Reason.Valid() {
+			return util.ValidationErrors{{Field: "reason", Message: "must be one of " + joinCancelReasons()}}
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		var fee *util.CancellationFee
+		err = cancelBookingWithFeeCheck(a, id, req.Reason, func(f *util.CancellationFee) bool {
+			fee = f
+			return req.AcceptFee
+		})
+		if errors.Is(err, errCancellationNotConfirmed) {
+			return util.NewGatewayError(http.StatusConflict, util.GatewayErrorFeeNotAccepted,
+				"a cancellation fee applies, send accept_fee to cancel anyway",
+				util.ErrorDetail{Message: "fee", Detail: fee.String()})
+		}
+		if err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	case id != "" && sub == "tracking" && r.Method == http.MethodGet:
+		err := checkBookingOwner(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		tracking, err := trackDriver(a, id)
+		if err != nil {
+			return err
+		}
+		writeGatewayJSON(w, http.StatusOK, tracking)
+		return nil
+	case id == "" || sub == "" || sub == "cancel" || sub == "tracking":
+		return errMethodNotAllowed
+	}
+	return errNoSuchEndpoint
+}
+
+// approvals POST /v1/approvals books a quote within the travel policy and parks it for approval otherwise,
+// GET /v1/approvals lists the pending requests of the caller, GET /v1/approvals/{id} gets one and
+// POST /v1/approvals/{id}/approve or /reject decides on it. Clients only see the approval requests they made
+func (g *gateway) approvals(w http.ResponseWriter, r *http.Request) error {
+	id, sub := splitGatewayPath(r.URL.Path, "/v1/approvals")
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req gatewayApprovalRequest
+		err := decodeGatewayRequest(w, r, &req)
+		if err != nil {
+			return err
+		}
+		err = req.validate()
+		if err != nil {
+			return err
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		quotesList, err := retrieveQuoteList(a, req.Booking.QuoteID[:strings.Index(req.Booking.QuoteID, ":")])
+		if err != nil {
+			return err
+		}
+		bookingDetails, approval, err := requestBooking(a, callerClient(r.Context()), req.Policy, quotesList,
+			*req.Origin, *req.Destination, req.PickupTime, req.Booking)
+		if err != nil {
+			return err
+		}
+		if approval != nil {
+			writeGatewayJSON(w, http.StatusAccepted, approval)
+			return nil
+		}
+		recordBookingOwner(r.Context(), bookingDetails.ID)
+		writeGatewayJSON(w, http.StatusCreated, bookingDetails)
+		return nil
+	case id == "" && r.Method == http.MethodGet:
+		pending, err := listPendingApprovals()
+		if err != nil {
+			return err
+		}
+		own := []*util.ApprovalRequest{}
+		for _, approval := range pending {
+			if approval.Client == callerClient(r.Context()) {
+				own = append(own, approval)
+			}
+		}
+		writeGatewayJSON(w, http.StatusOK, own)
+		return nil
+	case id != "" && sub == "" && r.Method == http.MethodGet:
+		approval, err := callerApproval(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		writeGatewayJSON(w, http.StatusOK, approval)
+		return nil
+	case id != "" && (sub == "approve" || sub == "reject") && r.Method == http.MethodPost:
+		_, err := callerApproval(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		var req gatewayDecisionRequest
+		err = decodeGatewayRequest(w, r, &req)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(req.Approver) == "" {
+			return util.ValidationErrors{{Field: "approver", Message: "is required"}}
+		}
+		if sub == "reject" {
+			err = rejectBooking(id, req.Approver, req.Reason)
+			if err != nil {
+				return err
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+		a, err := callerAuthInfo(r.Context(), g.auth)
+		if err != nil {
+			return err
+		}
+		bookingDetails, err := approveBooking(a, id, req.Approver)
+		if err != nil {
+			return err
+		}
+		recordBookingOwner(r.Context(), bookingDetails.ID)
+		writeGatewayJSON(w, http.StatusCreated, bookingDetails)
+		return nil
+	case id == "" || sub == "" || sub == "approve" || sub == "reject":
+		return errMethodNotAllowed
+	}
+	return errNoSuchEndpoint
+}
+
+// callerApproval returns an approval request the caller in ctx made
+func callerApproval(ctx context.Context, approvalID string) (*util.ApprovalRequest, error) {
+	approval, err := getApprovalRequest(approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Client != callerClient(ctx) {
+		return nil, errNoSuchApproval
+	}
+	return approval, nil
+}
+
+// statusRecorder remembers the status code of a response for the access log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+var (
+	errMethodNotAllowed = util.NewGatewayError(http.StatusMethodNotAllowed, util.GatewayErrorMethodNotAllowed, "method not allowed")
+	errNoSuchEndpoint   = util.NewGatewayError(http.StatusNotFound, util.GatewayErrorNotFound, "no such endpoint")
+)
+
+// validate checks the quote request before any karhoo call
+func (req *gatewayQuoteRequest) validate() error {
+	var errs util.ValidationErrors
+	for _, location := range []struct {
+		field       string
+		geolocation *util.Geolocation
+	}{{"origin", req.Origin}, {"destination", req.Destination}} {
+		if location.geolocation == nil {
+			errs = append(errs, util.ValidationError{Field: location.field, Message: "is required"})
+		} else if err := location.geolocation.Validate(); err != nil {
+			errs = append(errs, util.ValidationError{Field: location.field, Message: err.Error()})
+		}
+	}
+	if req.PickupTime != "" {
+		if _, err := time.Parse("2006-01-02T15:04", req.PickupTime); err != nil {
+			errs = append(errs, util.ValidationError{Field: "pickup_time", Message: "must be a local time like 2021-01-08T10:30"})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validate checks the approval request before any karhoo call
+func (req *gatewayApprovalRequest) validate() error {
+	var errs util.ValidationErrors
+	if err := req.gatewayQuoteRequest.validate(); err != nil && !errors.As(err, &errs) {
+		return err
+	}
+	if req.Booking == nil {
+		errs = append(errs, util.ValidationError{Field: "booking", Message: "is required"})
+	} else if err := req.Booking.Validate(); err != nil {
+		var bookingErrs util.ValidationErrors
+		if !errors.As(err, &bookingErrs) {
+			return err
+		}
+		for _, e := range bookingErrs {
+			errs = append(errs, util.ValidationError{Field: "booking." + e.Field, Message: e.Message})
+		}
+	} else if strings.Index(req.Booking.QuoteID, ":") <= 0 {
+		errs = append(errs, util.ValidationError{Field: "booking.quote_id", Message: "must be the ID of a quote of a quotes list"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// splitGatewayPath splits /prefix/{id}/{sub} into id and sub, both empty for the prefix itself
+func splitGatewayPath(path, prefix string) (id, sub string) {
+	rest := strings.Trim(strings.TrimPrefix(path, prefix), "/")
+	parts := strings.SplitN(rest, "/", 2)
+	id = parts[0]
+	if len(parts) == 2 {
+		sub = parts[1]
+	}
+	return id, sub
+}
+
+// decodeGatewayRequest decodes a json request body, rejecting unknown fields so that typos do not pass silently
+func decodeGatewayRequest(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGatewayRequestBody))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err != nil {
+		return util.NewGatewayError(http.StatusBadRequest, util.GatewayErrorBadRequest, "invalid json request body",
+			util.ErrorDetail{Message: err.Error()})
+	}
+	return nil
+}
+
+// writeGatewayJSON writes a json response, encoding errors are not reported as the status is already sent
+func writeGatewayJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeGatewayError(w http.ResponseWriter, e *util.GatewayError) {
+	writeGatewayJSON(w, e.StatusCode, e)
+}
@@ -2,4 +2,9 @@ module karhooAPIs.com
 
 go 1.14
 
-require gopkg.in/yaml.v2 v2.4.0
+require (
+	github.com/mattn/go-sqlite3 v1.14.8
+	google.golang.org/grpc v1.40.0
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v2 v2.4.0
+)
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"karhooAPIs.com/karhoopb"
+	"karhooAPIs.com/util"
+)
+
+// grpcServer implements the karhoo gRPC service on top of the same calls as the CLI and the HTTP gateway
+type grpcServer struct {
+	karhoopb.UnimplementedKarhooServer
+	auth *util.AuthInfo
+}
+
+func runGRPC(args []string) int {
+	fs := newFlagSet("grpc")
+	addr := fs.String("addr", ":9090", "address to listen on")
+	keysFile := fs.String("keys", "", `file with API keys, one "<client> <key>" per line, sent as x-api-key metadata (required)`)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *keysFile == "" {
+		return usageError(fs, "-keys is required")
+	}
+	f, err := os.Open(*keysFile)
+	if err != nil {
+		return fail(err)
+	}
+	keys, err := util.ParseAPIKeys(f)
+	f.Close()
+	if err != nil {
+		return fail(fmt.Errorf("%s: %w", *keysFile, err))
+	}
+	if keys.Len() == 0 {
+		fmt.Fprintf(os.Stderr, "error: no API keys in %s, the server would reject every call\n", *keysFile)
+		return exitUsage
+	}
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			start := time.Now()
+			ctx, err := grpcCaller(ctx, keys, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+			if err != nil {
+				logGRPCCall(ctx, info.FullMethod, start, err)
+				return nil, err
+			}
+			res, err := handler(ctx, req)
+			logGRPCCall(ctx, info.FullMethod, start, err)
+			return res, err
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			start := time.Now()
+			ctx, err := grpcCaller(ss.Context(), keys, ss.SetHeader)
+			if err != nil {
+				logGRPCCall(ctx, info.FullMethod, start, err)
+				return err
+			}
+			err = handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+			logGRPCCall(ctx, info.FullMethod, start, err)
+			return err
+		}),
+	}
+	if bookingLedger == nil {
+		return fail(errLedgerRequired)
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fail(err)
+	}
+	server := grpc.NewServer(options...)
+	karhoopb.RegisterKarhooServer(server, &grpcServer{auth: a})
+	go func() {
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		<-interrupt
+		server.GracefulStop()
+	}()
+	util.LogEvent(util.LevelInfo, "gRPC server listening", util.LogField("addr", *addr), util.LogField("api_keys", keys.Len()))
+	err = server.Serve(listener)
+	if err != nil {
+		return fail(err)
+	}
+	return exitOK
+}
+
+// checkGRPCAPIKey checks the x-api-key metadata of a call and returns the client it belongs to
+func checkGRPCAPIKey(ctx context.Context, keys *util.APIKeys) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range md.Get("x-api-key") {
+		if client, ok := keys.Client(key); ok {
+			return client, nil
+		}
+	}
+	return "", status.Error(codes.Unauthenticated, "missing or unknown API key")
+}
+
+// grpcCaller checks the API key of a call and adds the caller to its context. The correlation ID is sent back as
+// header metadata
+func grpcCaller(ctx context.Context, keys *util.APIKeys, setHeader func(metadata.MD) error) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c := caller{correlationID: correlationID(strings.Join(md.Get(correlationIDHeader), ""))}
+	setHeader(metadata.Pairs(correlationIDHeader, c.correlationID))
+	ctx = withCaller(ctx, c)
+	client, err := checkGRPCAPIKey(ctx, keys)
+	if err != nil {
+		return ctx, err
+	}
+	c.client = client
+	return withCaller(ctx, c), nil
+}
+
+// callerStream server stream with the caller in its context
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// logGRPCCall logs a finished call with its status code
+func logGRPCCall(ctx context.Context, method string, start time.Time, err error) {
+	c, _ := ctx.Value(callerKey{}).(caller)
+	level := util.LevelInfo
+	code := status.Code(err)
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.FailedPrecondition:
+	case codes.Unauthenticated:
+		level = util.LevelWarn
+	default:
+		level = util.LevelError
+	}
+	fields := []util.Field{util.LogField("correlation_id", c.correlationID), util.LogField("method", method),
+		util.LogField("code", code), util.LogField("latency_ms", time.Since(start).Milliseconds())}
+	if c.client != "" {
+		fields = append(fields, util.LogField("actor", c.client))
+	}
+	util.LogEvent(level, "gRPC call", fields...)
+}
+
+// grpcError maps an error to a gRPC status, with the same classification as the HTTP gateway
+func grpcError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	e := util.GatewayErrorFrom(err)
+	code := codes.Unknown
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict, http.StatusGone, http.StatusUnprocessableEntity:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		code = codes.Unavailable
+	case http.StatusInternalServerError:
+		code = codes.Internal
+		util.LogEvent(util.LevelError, "gRPC call failed", util.LogField("error", err))
+	}
+	message := e.Message
+	if e.Code != "" {
+		message = e.Code + ": " + message
+	}
+	for _, detail := range e.Details {
+		if detail.Detail != "" {
+			message += "; " + detail.Detail + ": " + detail.Message
+		} else {
+			message += "; " + detail.Message
+		}
+	}
+	return status.Error(code, message)
+}
+
+// transientPollError checks if polling a booking may succeed when retried, i.e. karhoo was unreachable or failed itself
+func transientPollError(err error) bool {
+	switch util.GatewayErrorFrom(err).StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
+
+// GetQuotes streams the quotes list each time fleets add quotes, a cached completed list is sent right away
+func (s *grpcServer) GetQuotes(req *karhoopb.GetQuotesRequest, stream karhoopb.Karhoo_GetQuotesServer) error {
+	if req.GetOrigin() == nil || req.GetDestination() == nil {
+		return status.Error(codes.InvalidArgument, "origin and destination are required")
+	}
+	origin := util.GeolocationFromProto(req.GetOrigin())
+	destination := util.GeolocationFromProto(req.GetDestination())
+	pickupTime := req.GetPickupTime()
+	if cached, ok := quoteCache.Get(origin, destination, pickupTime, time.Now()); ok {
+		return stream.Send(util.QuotesListToProto(cached))
+	}
+	a, err := callerAuthInfo(stream.Context(), s.auth)
+	if err != nil {
+		return grpcError(err)
+	}
+	err = checkRouteCoverage(a, origin, destination, pickupTime)
+	if err != nil {
+		return grpcError(err)
+	}
+	quotesList, err := getQuotes(a, origin, destination, pickupTime)
+	if err != nil {
+		return grpcError(err)
+	}
+	deadline := time.Now().Add(defaultBatchOptions.PollTimeout)
+	sent := -1
+	for {
+		quotesList, err = retrieveQuoteList(a, quotesList.ID)
+		if err != nil {
+			return grpcError(err)
+		}
+		completed := quotesList.Status != util.QuotesListProgressing
+		if len(quotesList.Quotes) != sent || completed {
+			err = stream.Send(util.QuotesListToProto(quotesList))
+			if err != nil {
+				return err
+			}
+			sent = len(quotesList.Quotes)
+		}
+		if completed {
+			quoteCache.Set(origin, destination, pickupTime, quotesList, time.Now())
+			return nil
+		}
+		if time.Now().Add(defaultBatchOptions.PollInterval).After(deadline) {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return grpcError(stream.Context().Err())
+		case <-time.After(defaultBatchOptions.PollInterval):
+		}
+	}
+}
+
+// Book books a quote, checking capacity and capabilities against the quote when its quotes list has it
+func (s *grpcServer) Book(ctx context.Context, req *karhoopb.BookingRequest) (*karhoopb.BookingDetails, error) {
+	bookingRequest := util.BookingRequestFromProto(req)
+	err := bookingRequest.Validate()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	a, err := callerAuthInfo(ctx, s.auth)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	quote, err := findQuote(a, bookingRequest.QuoteID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if quote != nil {
+		err = bookingRequest.ValidateForQuote(*quote)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+	}
+	bookingDetails, err := bookATrip(a, bookingRequest)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	recordBookingOwner(ctx, bookingDetails.ID)
+	if quote != nil {
+		recordQuoteSelection(*quote)
+	}
+	return util.BookingDetailsToProto(bookingDetails), nil
+}
+
+// GetBooking gets the details of a booking the caller made
+func (s *grpcServer) GetBooking(ctx context.Context, req *karhoopb.GetBookingRequest) (*karhoopb.BookingDetails, error) {
+	if req.GetBookingId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "booking_id is required")
+	}
+	err := checkBookingOwner(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	a, err := callerAuthInfo(ctx, s.auth)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	bookingDetails, err := getBookingDetails(a, req.GetBookingId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return util.BookingDetailsToProto(bookingDetails), nil
+}
+
+// CancelBooking cancels a booking the caller made if it is free of charge or the caller accepted the fee
+func (s *grpcServer) CancelBooking(ctx context.Context, req *karhoopb.CancelBookingRequest) (*karhoopb.CancelBookingResponse, error) {
+	if req.GetBookingId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "booking_id is required")
+	}
+	reason, err := util.ParseCancelReason(req.GetReason())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()+", use one of "+joinCancelReasons())
+	}
+	err = checkBookingOwner(ctx, req.GetBookingId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	a, err := callerAuthInfo(ctx, s.auth)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	var fee *util.CancellationFee
+	err = cancelBookingWithFeeCheck(a, req.GetBookingId(), reason, func(f *util.CancellationFee) bool {
+		fee = f
+		return req.GetAcceptFee()
+	})
+	if errors.Is(err, errCancellationNotConfirmed) {
+		return nil, status.Error(codes.FailedPrecondition, fee.String()+", set accept_fee to cancel anyway")
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	res := &karhoopb.CancelBookingResponse{}
+	if fee != nil && fee.Applies() {
+		res.Fee = &karhoopb.CancellationFee{Amount: int32(fee.Fee.Value), Currency: fee.Fee.Currency}
+	}
+	return res, nil
+}
+
+// WatchBooking streams a booking the caller made whenever it changes until it reaches a terminal status. Polling
+// continues while karhoo is unreachable or failing, other errors end the stream
+func (s *grpcServer) WatchBooking(req *karhoopb.WatchBookingRequest, stream karhoopb.Karhoo_WatchBookingServer) error {
+	if req.GetBookingId() == "" {
+		return status.Error(codes.InvalidArgument, "booking_id is required")
+	}
+	err := checkBookingOwner(stream.Context(), req.GetBookingId())
+	if err != nil {
+		return grpcError(err)
+	}
+	a, err := callerAuthInfo(stream.Context(), s.auth)
+	if err != nil {
+		return grpcError(err)
+	}
+	watcher := newBookingWatcher(a, util.DefaultPollIntervals)
+	defer watcher.Stop()
+	watcher.Watch(req.GetBookingId())
+	for {
+		select {
+		case <-stream.Context().Done():
+			return grpcError(stream.Context().Err())
+		case event := <-watcher.Events():
+			// an event with details but an error reports an unexpected status transition, the details are still valid
+			if event.Err != nil && event.Details == nil && !transientPollError(event.Err) {
+				return grpcError(event.Err)
+			}
+			if event.Err != nil {
+				continue
+			}
+			err := stream.Send(util.BookingEventToProto(event))
+			if err != nil {
+				return err
+			}
+			if event.Details.Status.IsTerminal() {
+				return nil
+			}
+		}
+	}
+}
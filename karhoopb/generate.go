@@ -0,0 +1,4 @@
+// Package karhoopb protobuf messages and gRPC service of the karhoo booking operations
+package karhoopb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative karhoo.proto
@@ -0,0 +1,2916 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: karhoo.proto
+
+// karhoo booking operations for gRPC backends. Messages mirror the JSON types of the util package, amounts are in the
+// smallest currency unit as in karhoo's API
+
+package karhoopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Position) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Position) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Geolocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position       *Position `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	DisplayAddress string    `protobuf:"bytes,2,opt,name=display_address,json=displayAddress,proto3" json:"display_address,omitempty"`
+	PlaceId        string    `protobuf:"bytes,3,opt,name=place_id,json=placeId,proto3" json:"place_id,omitempty"`
+	PoiType        string    `protobuf:"bytes,4,opt,name=poi_type,json=poiType,proto3" json:"poi_type,omitempty"`
+	Timezone       string    `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (x *Geolocation) Reset() {
+	*x = Geolocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Geolocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Geolocation) ProtoMessage() {}
+
+func (x *Geolocation) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Geolocation.ProtoReflect.Descriptor instead.
+func (*Geolocation) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Geolocation) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Geolocation) GetDisplayAddress() string {
+	if x != nil {
+		return x.DisplayAddress
+	}
+	return ""
+}
+
+func (x *Geolocation) GetPlaceId() string {
+	if x != nil {
+		return x.PlaceId
+	}
+	return ""
+}
+
+func (x *Geolocation) GetPoiType() string {
+	if x != nil {
+		return x.PoiType
+	}
+	return ""
+}
+
+func (x *Geolocation) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type GetQuotesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Origin      *Geolocation `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination *Geolocation `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// local pickup time, e.g. 2021-01-08T10:30, immediate pickup if empty
+	PickupTime string `protobuf:"bytes,3,opt,name=pickup_time,json=pickupTime,proto3" json:"pickup_time,omitempty"`
+}
+
+func (x *GetQuotesRequest) Reset() {
+	*x = GetQuotesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotesRequest) ProtoMessage() {}
+
+func (x *GetQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotesRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetQuotesRequest) GetOrigin() *Geolocation {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *GetQuotesRequest) GetDestination() *Geolocation {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *GetQuotesRequest) GetPickupTime() string {
+	if x != nil {
+		return x.PickupTime
+	}
+	return ""
+}
+
+type QuotesList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VehicleClasses []string `protobuf:"bytes,2,rep,name=vehicle_classes,json=vehicleClasses,proto3" json:"vehicle_classes,omitempty"`
+	VehicleTags    []string `protobuf:"bytes,3,rep,name=vehicle_tags,json=vehicleTags,proto3" json:"vehicle_tags,omitempty"`
+	VehicleTypes   []string `protobuf:"bytes,4,rep,name=vehicle_types,json=vehicleTypes,proto3" json:"vehicle_types,omitempty"`
+	Quotes         []*Quote `protobuf:"bytes,5,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	// PROGRESSING while fleets are still quoting, COMPLETED when all fleets quoted
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// seconds the quotes stay valid
+	Validity int32 `protobuf:"varint,7,opt,name=validity,proto3" json:"validity,omitempty"`
+}
+
+func (x *QuotesList) Reset() {
+	*x = QuotesList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuotesList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotesList) ProtoMessage() {}
+
+func (x *QuotesList) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotesList.ProtoReflect.Descriptor instead.
+func (*QuotesList) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QuotesList) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QuotesList) GetVehicleClasses() []string {
+	if x != nil {
+		return x.VehicleClasses
+	}
+	return nil
+}
+
+func (x *QuotesList) GetVehicleTags() []string {
+	if x != nil {
+		return x.VehicleTags
+	}
+	return nil
+}
+
+func (x *QuotesList) GetVehicleTypes() []string {
+	if x != nil {
+		return x.VehicleTypes
+	}
+	return nil
+}
+
+func (x *QuotesList) GetQuotes() []*Quote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *QuotesList) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *QuotesList) GetValidity() int32 {
+	if x != nil {
+		return x.Validity
+	}
+	return 0
+}
+
+type Quote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Price      *Quote_Price   `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	PickUpType string         `protobuf:"bytes,3,opt,name=pick_up_type,json=pickUpType,proto3" json:"pick_up_type,omitempty"`
+	QuoteType  string         `protobuf:"bytes,4,opt,name=quote_type,json=quoteType,proto3" json:"quote_type,omitempty"`
+	Source     string         `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	Fleet      *Quote_Fleet   `protobuf:"bytes,6,opt,name=fleet,proto3" json:"fleet,omitempty"`
+	Vehicle    *Quote_Vehicle `protobuf:"bytes,7,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Quote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Quote) GetPrice() *Quote_Price {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Quote) GetPickUpType() string {
+	if x != nil {
+		return x.PickUpType
+	}
+	return ""
+}
+
+func (x *Quote) GetQuoteType() string {
+	if x != nil {
+		return x.QuoteType
+	}
+	return ""
+}
+
+func (x *Quote) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Quote) GetFleet() *Quote_Fleet {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+func (x *Quote) GetVehicle() *Quote_Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+type Passenger struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FirstName   string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName    string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Email       string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	PhoneNumber string `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Locale      string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *Passenger) Reset() {
+	*x = Passenger{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Passenger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passenger) ProtoMessage() {}
+
+func (x *Passenger) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passenger.ProtoReflect.Descriptor instead.
+func (*Passenger) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Passenger) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Passenger) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Passenger) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Passenger) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Passenger) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type Passengers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AdditionalPassengers int32        `protobuf:"varint,1,opt,name=additional_passengers,json=additionalPassengers,proto3" json:"additional_passengers,omitempty"`
+	PassengerDetails     []*Passenger `protobuf:"bytes,2,rep,name=passenger_details,json=passengerDetails,proto3" json:"passenger_details,omitempty"`
+	Luggage              int32        `protobuf:"varint,3,opt,name=luggage,proto3" json:"luggage,omitempty"`
+}
+
+func (x *Passengers) Reset() {
+	*x = Passengers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Passengers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passengers) ProtoMessage() {}
+
+func (x *Passengers) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passengers.ProtoReflect.Descriptor instead.
+func (*Passengers) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Passengers) GetAdditionalPassengers() int32 {
+	if x != nil {
+		return x.AdditionalPassengers
+	}
+	return 0
+}
+
+func (x *Passengers) GetPassengerDetails() []*Passenger {
+	if x != nil {
+		return x.PassengerDetails
+	}
+	return nil
+}
+
+func (x *Passengers) GetLuggage() int32 {
+	if x != nil {
+		return x.Luggage
+	}
+	return 0
+}
+
+type BookingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuoteId             string            `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Passengers          *Passengers       `protobuf:"bytes,2,opt,name=passengers,proto3" json:"passengers,omitempty"`
+	FlightNumber        string            `protobuf:"bytes,3,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	TrainNumber         string            `protobuf:"bytes,4,opt,name=train_number,json=trainNumber,proto3" json:"train_number,omitempty"`
+	Comments            string            `protobuf:"bytes,5,opt,name=comments,proto3" json:"comments,omitempty"`
+	PartnerTripId       string            `protobuf:"bytes,6,opt,name=partner_trip_id,json=partnerTripId,proto3" json:"partner_trip_id,omitempty"`
+	CostCenterReference string            `protobuf:"bytes,7,opt,name=cost_center_reference,json=costCenterReference,proto3" json:"cost_center_reference,omitempty"`
+	Meta                map[string]string `protobuf:"bytes,8,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *BookingRequest) Reset() {
+	*x = BookingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingRequest) ProtoMessage() {}
+
+func (x *BookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingRequest.ProtoReflect.Descriptor instead.
+func (*BookingRequest) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BookingRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *BookingRequest) GetPassengers() *Passengers {
+	if x != nil {
+		return x.Passengers
+	}
+	return nil
+}
+
+func (x *BookingRequest) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *BookingRequest) GetTrainNumber() string {
+	if x != nil {
+		return x.TrainNumber
+	}
+	return ""
+}
+
+func (x *BookingRequest) GetComments() string {
+	if x != nil {
+		return x.Comments
+	}
+	return ""
+}
+
+func (x *BookingRequest) GetPartnerTripId() string {
+	if x != nil {
+		return x.PartnerTripId
+	}
+	return ""
+}
+
+func (x *BookingRequest) GetCostCenterReference() string {
+	if x != nil {
+		return x.CostCenterReference
+	}
+	return ""
+}
+
+func (x *BookingRequest) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type BreakdownItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value       int32  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *BreakdownItem) Reset() {
+	*x = BreakdownItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreakdownItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakdownItem) ProtoMessage() {}
+
+func (x *BreakdownItem) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakdownItem.ProtoReflect.Descriptor instead.
+func (*BreakdownItem) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BreakdownItem) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *BreakdownItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BreakdownItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Fare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total           int32            `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Currency        string           `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	GratuityPercent int32            `protobuf:"varint,3,opt,name=gratuity_percent,json=gratuityPercent,proto3" json:"gratuity_percent,omitempty"`
+	Breakdown       []*BreakdownItem `protobuf:"bytes,4,rep,name=breakdown,proto3" json:"breakdown,omitempty"`
+}
+
+func (x *Fare) Reset() {
+	*x = Fare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fare) ProtoMessage() {}
+
+func (x *Fare) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fare.ProtoReflect.Descriptor instead.
+func (*Fare) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Fare) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Fare) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Fare) GetGratuityPercent() int32 {
+	if x != nil {
+		return x.GratuityPercent
+	}
+	return 0
+}
+
+func (x *Fare) GetBreakdown() []*BreakdownItem {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+type BookingDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Passengers         *Passengers `protobuf:"bytes,2,opt,name=passengers,proto3" json:"passengers,omitempty"`
+	PartnerTravellerId string      `protobuf:"bytes,3,opt,name=partner_traveller_id,json=partnerTravellerId,proto3" json:"partner_traveller_id,omitempty"`
+	// booking status, e.g. CONFIRMED or DRIVER_EN_ROUTE
+	Status              string                       `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StateDetails        string                       `protobuf:"bytes,5,opt,name=state_details,json=stateDetails,proto3" json:"state_details,omitempty"`
+	Origin              *Geolocation                 `protobuf:"bytes,6,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination         *Geolocation                 `protobuf:"bytes,7,opt,name=destination,proto3" json:"destination,omitempty"`
+	DateScheduled       *timestamppb.Timestamp       `protobuf:"bytes,8,opt,name=date_scheduled,json=dateScheduled,proto3" json:"date_scheduled,omitempty"`
+	Quote               *BookingDetails_Quote        `protobuf:"bytes,9,opt,name=quote,proto3" json:"quote,omitempty"`
+	Fare                *Fare                        `protobuf:"bytes,10,opt,name=fare,proto3" json:"fare,omitempty"`
+	ExternalTripId      string                       `protobuf:"bytes,11,opt,name=external_trip_id,json=externalTripId,proto3" json:"external_trip_id,omitempty"`
+	DisplayTripId       string                       `protobuf:"bytes,12,opt,name=display_trip_id,json=displayTripId,proto3" json:"display_trip_id,omitempty"`
+	Fleet               *BookingDetails_Fleet        `protobuf:"bytes,13,opt,name=fleet,proto3" json:"fleet,omitempty"`
+	Vehicle             *BookingDetails_Vehicle      `protobuf:"bytes,14,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	PartnerTripId       string                       `protobuf:"bytes,15,opt,name=partner_trip_id,json=partnerTripId,proto3" json:"partner_trip_id,omitempty"`
+	Comments            string                       `protobuf:"bytes,16,opt,name=comments,proto3" json:"comments,omitempty"`
+	FlightNumber        string                       `protobuf:"bytes,17,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	TrainNumber         string                       `protobuf:"bytes,18,opt,name=train_number,json=trainNumber,proto3" json:"train_number,omitempty"`
+	DateBooked          string                       `protobuf:"bytes,19,opt,name=date_booked,json=dateBooked,proto3" json:"date_booked,omitempty"`
+	MeetingPoint        *BookingDetails_MeetingPoint `protobuf:"bytes,20,opt,name=meeting_point,json=meetingPoint,proto3" json:"meeting_point,omitempty"`
+	CostCenterReference string                       `protobuf:"bytes,21,opt,name=cost_center_reference,json=costCenterReference,proto3" json:"cost_center_reference,omitempty"`
+	FollowCode          string                       `protobuf:"bytes,22,opt,name=follow_code,json=followCode,proto3" json:"follow_code,omitempty"`
+}
+
+func (x *BookingDetails) Reset() {
+	*x = BookingDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingDetails) ProtoMessage() {}
+
+func (x *BookingDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingDetails.ProtoReflect.Descriptor instead.
+func (*BookingDetails) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BookingDetails) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetPassengers() *Passengers {
+	if x != nil {
+		return x.Passengers
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetPartnerTravellerId() string {
+	if x != nil {
+		return x.PartnerTravellerId
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetStateDetails() string {
+	if x != nil {
+		return x.StateDetails
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetOrigin() *Geolocation {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetDestination() *Geolocation {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetDateScheduled() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateScheduled
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetQuote() *BookingDetails_Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetFare() *Fare {
+	if x != nil {
+		return x.Fare
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetExternalTripId() string {
+	if x != nil {
+		return x.ExternalTripId
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetDisplayTripId() string {
+	if x != nil {
+		return x.DisplayTripId
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetFleet() *BookingDetails_Fleet {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetVehicle() *BookingDetails_Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetPartnerTripId() string {
+	if x != nil {
+		return x.PartnerTripId
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetComments() string {
+	if x != nil {
+		return x.Comments
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetTrainNumber() string {
+	if x != nil {
+		return x.TrainNumber
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetDateBooked() string {
+	if x != nil {
+		return x.DateBooked
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetMeetingPoint() *BookingDetails_MeetingPoint {
+	if x != nil {
+		return x.MeetingPoint
+	}
+	return nil
+}
+
+func (x *BookingDetails) GetCostCenterReference() string {
+	if x != nil {
+		return x.CostCenterReference
+	}
+	return ""
+}
+
+func (x *BookingDetails) GetFollowCode() string {
+	if x != nil {
+		return x.FollowCode
+	}
+	return ""
+}
+
+type GetBookingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookingId string `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+}
+
+func (x *GetBookingRequest) Reset() {
+	*x = GetBookingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingRequest) ProtoMessage() {}
+
+func (x *GetBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingRequest) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+type CancelBookingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookingId string `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	// one of util.CancelBookingReasons, e.g. NOT_NEEDED_ANYMORE
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// cancel even if a cancellation fee applies
+	AcceptFee bool `protobuf:"varint,3,opt,name=accept_fee,json=acceptFee,proto3" json:"accept_fee,omitempty"`
+}
+
+func (x *CancelBookingRequest) Reset() {
+	*x = CancelBookingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingRequest) ProtoMessage() {}
+
+func (x *CancelBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingRequest.ProtoReflect.Descriptor instead.
+func (*CancelBookingRequest) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+func (x *CancelBookingRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelBookingRequest) GetAcceptFee() bool {
+	if x != nil {
+		return x.AcceptFee
+	}
+	return false
+}
+
+type CancelBookingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// fee charged for the cancellation, unset if it was free
+	Fee *CancellationFee `protobuf:"bytes,1,opt,name=fee,proto3" json:"fee,omitempty"`
+}
+
+func (x *CancelBookingResponse) Reset() {
+	*x = CancelBookingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBookingResponse) ProtoMessage() {}
+
+func (x *CancelBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBookingResponse.ProtoReflect.Descriptor instead.
+func (*CancelBookingResponse) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelBookingResponse) GetFee() *CancellationFee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+type CancellationFee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount   int32  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *CancellationFee) Reset() {
+	*x = CancellationFee{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationFee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationFee) ProtoMessage() {}
+
+func (x *CancellationFee) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationFee.ProtoReflect.Descriptor instead.
+func (*CancellationFee) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancellationFee) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CancellationFee) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type WatchBookingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookingId string `protobuf:"bytes,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+}
+
+func (x *WatchBookingRequest) Reset() {
+	*x = WatchBookingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBookingRequest) ProtoMessage() {}
+
+func (x *WatchBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBookingRequest.ProtoReflect.Descriptor instead.
+func (*WatchBookingRequest) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchBookingRequest) GetBookingId() string {
+	if x != nil {
+		return x.BookingId
+	}
+	return ""
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Old   string `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New   string `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+type BookingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Details *BookingDetails `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	// changed fields since the previous event, empty for the first event
+	Changes []*FieldChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *BookingEvent) Reset() {
+	*x = BookingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingEvent) ProtoMessage() {}
+
+func (x *BookingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingEvent.ProtoReflect.Descriptor instead.
+func (*BookingEvent) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BookingEvent) GetDetails() *BookingDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type Quote_Price struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrencyCode string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	High         int32  `protobuf:"varint,2,opt,name=high,proto3" json:"high,omitempty"`
+	Low          int32  `protobuf:"varint,3,opt,name=low,proto3" json:"low,omitempty"`
+	NetHigh      int32  `protobuf:"varint,4,opt,name=net_high,json=netHigh,proto3" json:"net_high,omitempty"`
+	NetLow       int32  `protobuf:"varint,5,opt,name=net_low,json=netLow,proto3" json:"net_low,omitempty"`
+}
+
+func (x *Quote_Price) Reset() {
+	*x = Quote_Price{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote_Price) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote_Price) ProtoMessage() {}
+
+func (x *Quote_Price) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote_Price.ProtoReflect.Descriptor instead.
+func (*Quote_Price) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *Quote_Price) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Quote_Price) GetHigh() int32 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Quote_Price) GetLow() int32 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Quote_Price) GetNetHigh() int32 {
+	if x != nil {
+		return x.NetHigh
+	}
+	return 0
+}
+
+func (x *Quote_Price) GetNetLow() int32 {
+	if x != nil {
+		return x.NetLow
+	}
+	return 0
+}
+
+type Quote_Fleet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	RatingCount        int32    `protobuf:"varint,4,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	RatingScore        int32    `protobuf:"varint,5,opt,name=rating_score,json=ratingScore,proto3" json:"rating_score,omitempty"`
+	PhoneNumber        string   `protobuf:"bytes,6,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Capabilities       []string `protobuf:"bytes,7,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	LogoUrl            string   `protobuf:"bytes,8,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty"`
+	TermsConditionsUrl string   `protobuf:"bytes,9,opt,name=terms_conditions_url,json=termsConditionsUrl,proto3" json:"terms_conditions_url,omitempty"`
+}
+
+func (x *Quote_Fleet) Reset() {
+	*x = Quote_Fleet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote_Fleet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote_Fleet) ProtoMessage() {}
+
+func (x *Quote_Fleet) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote_Fleet.ProtoReflect.Descriptor instead.
+func (*Quote_Fleet) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *Quote_Fleet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Quote_Fleet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Quote_Fleet) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Quote_Fleet) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Quote_Fleet) GetRatingScore() int32 {
+	if x != nil {
+		return x.RatingScore
+	}
+	return 0
+}
+
+func (x *Quote_Fleet) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Quote_Fleet) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Quote_Fleet) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *Quote_Fleet) GetTermsConditionsUrl() string {
+	if x != nil {
+		return x.TermsConditionsUrl
+	}
+	return ""
+}
+
+type Quote_Vehicle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QtaHighMinutes    int32    `protobuf:"varint,1,opt,name=qta_high_minutes,json=qtaHighMinutes,proto3" json:"qta_high_minutes,omitempty"`
+	QtaLowMinutes     int32    `protobuf:"varint,2,opt,name=qta_low_minutes,json=qtaLowMinutes,proto3" json:"qta_low_minutes,omitempty"`
+	Class             string   `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	Type              string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	PassengerCapacity int32    `protobuf:"varint,5,opt,name=passenger_capacity,json=passengerCapacity,proto3" json:"passenger_capacity,omitempty"`
+	LuggageCapacity   int32    `protobuf:"varint,6,opt,name=luggage_capacity,json=luggageCapacity,proto3" json:"luggage_capacity,omitempty"`
+	Tags              []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *Quote_Vehicle) Reset() {
+	*x = Quote_Vehicle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote_Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote_Vehicle) ProtoMessage() {}
+
+func (x *Quote_Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote_Vehicle.ProtoReflect.Descriptor instead.
+func (*Quote_Vehicle) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *Quote_Vehicle) GetQtaHighMinutes() int32 {
+	if x != nil {
+		return x.QtaHighMinutes
+	}
+	return 0
+}
+
+func (x *Quote_Vehicle) GetQtaLowMinutes() int32 {
+	if x != nil {
+		return x.QtaLowMinutes
+	}
+	return 0
+}
+
+func (x *Quote_Vehicle) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *Quote_Vehicle) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Quote_Vehicle) GetPassengerCapacity() int32 {
+	if x != nil {
+		return x.PassengerCapacity
+	}
+	return 0
+}
+
+func (x *Quote_Vehicle) GetLuggageCapacity() int32 {
+	if x != nil {
+		return x.LuggageCapacity
+	}
+	return 0
+}
+
+func (x *Quote_Vehicle) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type BookingDetails_Quote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type           string           `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Total          int32            `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Currency       string           `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Breakdown      []*BreakdownItem `protobuf:"bytes,4,rep,name=breakdown,proto3" json:"breakdown,omitempty"`
+	VehicleClass   string           `protobuf:"bytes,5,opt,name=vehicle_class,json=vehicleClass,proto3" json:"vehicle_class,omitempty"`
+	QtaHighMinutes int32            `protobuf:"varint,6,opt,name=qta_high_minutes,json=qtaHighMinutes,proto3" json:"qta_high_minutes,omitempty"`
+	QtaLowMinutes  int32            `protobuf:"varint,7,opt,name=qta_low_minutes,json=qtaLowMinutes,proto3" json:"qta_low_minutes,omitempty"`
+	Source         string           `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	HighPrice      int32            `protobuf:"varint,9,opt,name=high_price,json=highPrice,proto3" json:"high_price,omitempty"`
+	LowPrice       int32            `protobuf:"varint,10,opt,name=low_price,json=lowPrice,proto3" json:"low_price,omitempty"`
+}
+
+func (x *BookingDetails_Quote) Reset() {
+	*x = BookingDetails_Quote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingDetails_Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingDetails_Quote) ProtoMessage() {}
+
+func (x *BookingDetails_Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingDetails_Quote.ProtoReflect.Descriptor instead.
+func (*BookingDetails_Quote) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *BookingDetails_Quote) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BookingDetails_Quote) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BookingDetails_Quote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BookingDetails_Quote) GetBreakdown() []*BreakdownItem {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *BookingDetails_Quote) GetVehicleClass() string {
+	if x != nil {
+		return x.VehicleClass
+	}
+	return ""
+}
+
+func (x *BookingDetails_Quote) GetQtaHighMinutes() int32 {
+	if x != nil {
+		return x.QtaHighMinutes
+	}
+	return 0
+}
+
+func (x *BookingDetails_Quote) GetQtaLowMinutes() int32 {
+	if x != nil {
+		return x.QtaLowMinutes
+	}
+	return 0
+}
+
+func (x *BookingDetails_Quote) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *BookingDetails_Quote) GetHighPrice() int32 {
+	if x != nil {
+		return x.HighPrice
+	}
+	return 0
+}
+
+func (x *BookingDetails_Quote) GetLowPrice() int32 {
+	if x != nil {
+		return x.LowPrice
+	}
+	return 0
+}
+
+type BookingDetails_Fleet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	PhoneNumber        string `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Email              string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	LogoUrl            string `protobuf:"bytes,6,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty"`
+	TermsConditionsUrl string `protobuf:"bytes,7,opt,name=terms_conditions_url,json=termsConditionsUrl,proto3" json:"terms_conditions_url,omitempty"`
+}
+
+func (x *BookingDetails_Fleet) Reset() {
+	*x = BookingDetails_Fleet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingDetails_Fleet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingDetails_Fleet) ProtoMessage() {}
+
+func (x *BookingDetails_Fleet) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingDetails_Fleet.ProtoReflect.Descriptor instead.
+func (*BookingDetails_Fleet) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{10, 1}
+}
+
+func (x *BookingDetails_Fleet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BookingDetails_Fleet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BookingDetails_Fleet) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BookingDetails_Fleet) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *BookingDetails_Fleet) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *BookingDetails_Fleet) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *BookingDetails_Fleet) GetTermsConditionsUrl() string {
+	if x != nil {
+		return x.TermsConditionsUrl
+	}
+	return ""
+}
+
+type BookingDetails_Driver struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FirstName     string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	PhoneNumber   string `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	LicenseNumber string `protobuf:"bytes,4,opt,name=license_number,json=licenseNumber,proto3" json:"license_number,omitempty"`
+	PhotoUrl      string `protobuf:"bytes,5,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+}
+
+func (x *BookingDetails_Driver) Reset() {
+	*x = BookingDetails_Driver{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingDetails_Driver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingDetails_Driver) ProtoMessage() {}
+
+func (x *BookingDetails_Driver) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingDetails_Driver.ProtoReflect.Descriptor instead.
+func (*BookingDetails_Driver) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{10, 2}
+}
+
+func (x *BookingDetails_Driver) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *BookingDetails_Driver) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *BookingDetails_Driver) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *BookingDetails_Driver) GetLicenseNumber() string {
+	if x != nil {
+		return x.LicenseNumber
+	}
+	return ""
+}
+
+func (x *BookingDetails_Driver) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+type BookingDetails_Vehicle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VehicleClass      string                 `protobuf:"bytes,1,opt,name=vehicle_class,json=vehicleClass,proto3" json:"vehicle_class,omitempty"`
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	LicensePlate      string                 `protobuf:"bytes,3,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	Driver            *BookingDetails_Driver `protobuf:"bytes,4,opt,name=driver,proto3" json:"driver,omitempty"`
+	PassengerCapacity int32                  `protobuf:"varint,5,opt,name=passenger_capacity,json=passengerCapacity,proto3" json:"passenger_capacity,omitempty"`
+	LuggageCapacity   int32                  `protobuf:"varint,6,opt,name=luggage_capacity,json=luggageCapacity,proto3" json:"luggage_capacity,omitempty"`
+}
+
+func (x *BookingDetails_Vehicle) Reset() {
+	*x = BookingDetails_Vehicle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingDetails_Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingDetails_Vehicle) ProtoMessage() {}
+
+func (x *BookingDetails_Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingDetails_Vehicle.ProtoReflect.Descriptor instead.
+func (*BookingDetails_Vehicle) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{10, 3}
+}
+
+func (x *BookingDetails_Vehicle) GetVehicleClass() string {
+	if x != nil {
+		return x.VehicleClass
+	}
+	return ""
+}
+
+func (x *BookingDetails_Vehicle) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *BookingDetails_Vehicle) GetLicensePlate() string {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return ""
+}
+
+func (x *BookingDetails_Vehicle) GetDriver() *BookingDetails_Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+func (x *BookingDetails_Vehicle) GetPassengerCapacity() int32 {
+	if x != nil {
+		return x.PassengerCapacity
+	}
+	return 0
+}
+
+func (x *BookingDetails_Vehicle) GetLuggageCapacity() int32 {
+	if x != nil {
+		return x.LuggageCapacity
+	}
+	return 0
+}
+
+type BookingDetails_MeetingPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position     *Position `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Type         string    `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Instructions string    `protobuf:"bytes,3,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	Note         string    `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *BookingDetails_MeetingPoint) Reset() {
+	*x = BookingDetails_MeetingPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_karhoo_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookingDetails_MeetingPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingDetails_MeetingPoint) ProtoMessage() {}
+
+func (x *BookingDetails_MeetingPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_karhoo_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingDetails_MeetingPoint.ProtoReflect.Descriptor instead.
+func (*BookingDetails_MeetingPoint) Descriptor() ([]byte, []int) {
+	return file_karhoo_proto_rawDescGZIP(), []int{10, 4}
+}
+
+func (x *BookingDetails_MeetingPoint) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *BookingDetails_MeetingPoint) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BookingDetails_MeetingPoint) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *BookingDetails_MeetingPoint) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+var File_karhoo_proto protoreflect.FileDescriptor
+
+var file_karhoo_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x44, 0x0a, 0x08, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x22, 0xb9, 0x01, 0x0a, 0x0b, 0x47, 0x65, 0x6f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2f, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f, 0x69, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x69, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x9d, 0x01, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2e, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x6f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x12, 0x38, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x6f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
+	0x69, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xeb, 0x01, 0x0a,
+	0x0a, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x76,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f,
+	0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x06,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6b,
+	0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x06,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x22, 0xa9, 0x07, 0x0a, 0x05, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x69, 0x63, 0x6b, 0x5f, 0x75, 0x70, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x69, 0x63, 0x6b, 0x55, 0x70,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x66,
+	0x6c, 0x65, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x61, 0x72,
+	0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x2e, 0x46, 0x6c, 0x65,
+	0x65, 0x74, 0x52, 0x05, 0x66, 0x6c, 0x65, 0x65, 0x74, 0x12, 0x32, 0x0a, 0x07, 0x76, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x61, 0x72,
+	0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x2e, 0x56, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x52, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x1a, 0x86, 0x01,
+	0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x69, 0x67, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6c,
+	0x6f, 0x77, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x48, 0x69, 0x67, 0x68, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x6e, 0x65, 0x74, 0x4c, 0x6f, 0x77, 0x1a, 0xa7, 0x02, 0x0a, 0x05, 0x46, 0x6c, 0x65, 0x65, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x30,
+	0x0a, 0x14, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x74, 0x65,
+	0x72, 0x6d, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x55, 0x72, 0x6c,
+	0x1a, 0xf3, 0x01, 0x0a, 0x07, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x71, 0x74, 0x61, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x71, 0x74, 0x61, 0x48, 0x69, 0x67, 0x68, 0x4d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x71, 0x74, 0x61, 0x5f, 0x6c, 0x6f,
+	0x77, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x71, 0x74, 0x61, 0x4c, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x61, 0x73, 0x73,
+	0x65, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x43,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x75, 0x67, 0x67, 0x61,
+	0x67, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x6c, 0x75, 0x67, 0x67, 0x61, 0x67, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x09, 0x50, 0x61, 0x73, 0x73, 0x65,
+	0x6e, 0x67, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x68,
+	0x6f, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x65, 0x22, 0x9e, 0x01, 0x0a, 0x0a, 0x50, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73,
+	0x12, 0x33, 0x0a, 0x15, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x70,
+	0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x14, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x50, 0x61, 0x73, 0x73, 0x65,
+	0x6e, 0x67, 0x65, 0x72, 0x73, 0x12, 0x41, 0x0a, 0x11, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67,
+	0x65, 0x72, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x73,
+	0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x52, 0x10, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65,
+	0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x75, 0x67, 0x67,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6c, 0x75, 0x67, 0x67, 0x61,
+	0x67, 0x65, 0x22, 0x94, 0x03, 0x0a, 0x0e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x49, 0x64,
+	0x12, 0x35, 0x0a, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6c, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x70,
+	0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x54, 0x72, 0x69,
+	0x70, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x13, 0x63, 0x6f, 0x73, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61,
+	0x1a, 0x37, 0x0a, 0x09, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5b, 0x0a, 0x0d, 0x42, 0x72, 0x65,
+	0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9b, 0x01, 0x0a, 0x04, 0x46, 0x61, 0x72, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x72, 0x61, 0x74, 0x75, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x67, 0x72, 0x61,
+	0x74, 0x75, 0x69, 0x74, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x09,
+	0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x61,
+	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x09, 0x62, 0x72, 0x65, 0x61, 0x6b,
+	0x64, 0x6f, 0x77, 0x6e, 0x22, 0xd5, 0x10, 0x0a, 0x0e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x35, 0x0a, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x65,
+	0x6e, 0x67, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x61,
+	0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65,
+	0x72, 0x73, 0x52, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x12, 0x30,
+	0x0a, 0x14, 0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c,
+	0x6c, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x61,
+	0x72, 0x74, 0x6e, 0x65, 0x72, 0x54, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2e, 0x0a,
+	0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6f, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x38, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x6f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0e, 0x64, 0x61, 0x74, 0x65, 0x5f,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x05, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x61, 0x72, 0x68,
+	0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74,
+	0x65, 0x12, 0x23, 0x0a, 0x04, 0x66, 0x61, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x72, 0x65,
+	0x52, 0x04, 0x66, 0x61, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x69, 0x70, 0x49, 0x64,
+	0x12, 0x26, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x74, 0x72, 0x69, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x54, 0x72, 0x69, 0x70, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x05, 0x66, 0x6c, 0x65, 0x65,
+	0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x2e, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x52, 0x05, 0x66, 0x6c, 0x65, 0x65, 0x74, 0x12,
+	0x3b, 0x0a, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x56, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x52, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x26, 0x0a, 0x0f,
+	0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x69, 0x64, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x54, 0x72,
+	0x69, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x62, 0x6f, 0x6f, 0x6b, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x65, 0x64, 0x12, 0x4b, 0x0a, 0x0d, 0x6d, 0x65, 0x65,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x4d, 0x65, 0x65, 0x74,
+	0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x6d, 0x65, 0x65, 0x74, 0x69, 0x6e,
+	0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x63,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x6f, 0x73, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0xd0, 0x02, 0x0a, 0x05,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x36, 0x0a, 0x09, 0x62,
+	0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x64, 0x6f, 0x77, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x09, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64,
+	0x6f, 0x77, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x71, 0x74, 0x61, 0x5f,
+	0x68, 0x69, 0x67, 0x68, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x71, 0x74, 0x61, 0x48, 0x69, 0x67, 0x68, 0x4d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x71, 0x74, 0x61, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x69,
+	0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x71, 0x74, 0x61,
+	0x4c, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x68, 0x69, 0x67, 0x68, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x63, 0x65, 0x1a, 0xd3,
+	0x01, 0x0a, 0x05, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x6f, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x6f, 0x55,
+	0x72, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x12, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x55, 0x72, 0x6c, 0x1a, 0xab, 0x01, 0x0a, 0x06, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70,
+	0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25,
+	0x0a, 0x0e, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x55,
+	0x72, 0x6c, 0x1a, 0x89, 0x02, 0x0a, 0x07, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65,
+	0x5f, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x69,
+	0x63, 0x65, 0x6e, 0x73, 0x65, 0x50, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x64, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x61, 0x72,
+	0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x44, 0x72, 0x69, 0x76, 0x65, 0x72, 0x52, 0x06, 0x64, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65,
+	0x72, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x11, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x75, 0x67, 0x67, 0x61, 0x67, 0x65, 0x5f, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6c,
+	0x75, 0x67, 0x67, 0x61, 0x67, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x1a, 0x8b,
+	0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x2f, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x22, 0x32, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64,
+	0x22, 0x6c, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6b,
+	0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6f,
+	0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x46, 0x65, 0x65, 0x22, 0x45,
+	0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65,
+	0x52, 0x03, 0x66, 0x65, 0x65, 0x22, 0x45, 0x0a, 0x0f, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x34, 0x0a, 0x13,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x49, 0x64, 0x22, 0x47, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x6c, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x65, 0x77,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x65, 0x77, 0x22, 0x75, 0x0a, 0x0c, 0x42,
+	0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b,
+	0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x12, 0x30, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x32, 0xef, 0x02, 0x0a, 0x06, 0x4b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x12, 0x41, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x6b, 0x61, 0x72,
+	0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x30, 0x01,
+	0x12, 0x3c, 0x0a, 0x04, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x19, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x45,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x2e, 0x6b,
+	0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x6b,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6b, 0x61, 0x72,
+	0x68, 0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x52, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42,
+	0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x2e, 0x6b, 0x61, 0x72, 0x68,
+	0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6f, 0x6f, 0x6b, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6b, 0x61, 0x72, 0x68,
+	0x6f, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x30, 0x01, 0x42, 0x19, 0x5a, 0x17, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x41, 0x50,
+	0x49, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x61, 0x72, 0x68, 0x6f, 0x6f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_karhoo_proto_rawDescOnce sync.Once
+	file_karhoo_proto_rawDescData = file_karhoo_proto_rawDesc
+)
+
+func file_karhoo_proto_rawDescGZIP() []byte {
+	file_karhoo_proto_rawDescOnce.Do(func() {
+		file_karhoo_proto_rawDescData = protoimpl.X.CompressGZIP(file_karhoo_proto_rawDescData)
+	})
+	return file_karhoo_proto_rawDescData
+}
+
+var file_karhoo_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_karhoo_proto_goTypes = []interface{}{
+	(*Position)(nil),                    // 0: karhoo.v1.Position
+	(*Geolocation)(nil),                 // 1: karhoo.v1.Geolocation
+	(*GetQuotesRequest)(nil),            // 2: karhoo.v1.GetQuotesRequest
+	(*QuotesList)(nil),                  // 3: karhoo.v1.QuotesList
+	(*Quote)(nil),                       // 4: karhoo.v1.Quote
+	(*Passenger)(nil),                   // 5: karhoo.v1.Passenger
+	(*Passengers)(nil),                  // 6: karhoo.v1.Passengers
+	(*BookingRequest)(nil),              // 7: karhoo.v1.BookingRequest
+	(*BreakdownItem)(nil),               // 8: karhoo.v1.BreakdownItem
+	(*Fare)(nil),                        // 9: karhoo.v1.Fare
+	(*BookingDetails)(nil),              // 10: karhoo.v1.BookingDetails
+	(*GetBookingRequest)(nil),           // 11: karhoo.v1.GetBookingRequest
+	(*CancelBookingRequest)(nil),        // 12: karhoo.v1.CancelBookingRequest
+	(*CancelBookingResponse)(nil),       // 13: karhoo.v1.CancelBookingResponse
+	(*CancellationFee)(nil),             // 14: karhoo.v1.CancellationFee
+	(*WatchBookingRequest)(nil),         // 15: karhoo.v1.WatchBookingRequest
+	(*FieldChange)(nil),                 // 16: karhoo.v1.FieldChange
+	(*BookingEvent)(nil),                // 17: karhoo.v1.BookingEvent
+	(*Quote_Price)(nil),                 // 18: karhoo.v1.Quote.Price
+	(*Quote_Fleet)(nil),                 // 19: karhoo.v1.Quote.Fleet
+	(*Quote_Vehicle)(nil),               // 20: karhoo.v1.Quote.Vehicle
+	nil,                                 // 21: karhoo.v1.BookingRequest.MetaEntry
+	(*BookingDetails_Quote)(nil),        // 22: karhoo.v1.BookingDetails.Quote
+	(*BookingDetails_Fleet)(nil),        // 23: karhoo.v1.BookingDetails.Fleet
+	(*BookingDetails_Driver)(nil),       // 24: karhoo.v1.BookingDetails.Driver
+	(*BookingDetails_Vehicle)(nil),      // 25: karhoo.v1.BookingDetails.Vehicle
+	(*BookingDetails_MeetingPoint)(nil), // 26: karhoo.v1.BookingDetails.MeetingPoint
+	(*timestamppb.Timestamp)(nil),       // 27: google.protobuf.Timestamp
+}
+var file_karhoo_proto_depIdxs = []int32{
+	0,  // 0: karhoo.v1.Geolocation.position:type_name -> karhoo.v1.Position
+	1,  // 1: karhoo.v1.GetQuotesRequest.origin:type_name -> karhoo.v1.Geolocation
+	1,  // 2: karhoo.v1.GetQuotesRequest.destination:type_name -> karhoo.v1.Geolocation
+	4,  // 3: karhoo.v1.QuotesList.quotes:type_name -> karhoo.v1.Quote
+	18, // 4: karhoo.v1.Quote.price:type_name -> karhoo.v1.Quote.Price
+	19, // 5: karhoo.v1.Quote.fleet:type_name -> karhoo.v1.Quote.Fleet
+	20, // 6: karhoo.v1.Quote.vehicle:type_name -> karhoo.v1.Quote.Vehicle
+	5,  // 7: karhoo.v1.Passengers.passenger_details:type_name -> karhoo.v1.Passenger
+	6,  // 8: karhoo.v1.BookingRequest.passengers:type_name -> karhoo.v1.Passengers
+	21, // 9: karhoo.v1.BookingRequest.meta:type_name -> karhoo.v1.BookingRequest.MetaEntry
+	8,  // 10: karhoo.v1.Fare.breakdown:type_name -> karhoo.v1.BreakdownItem
+	6,  // 11: karhoo.v1.BookingDetails.passengers:type_name -> karhoo.v1.Passengers
+	1,  // 12: karhoo.v1.BookingDetails.origin:type_name -> karhoo.v1.Geolocation
+	1,  // 13: karhoo.v1.BookingDetails.destination:type_name -> karhoo.v1.Geolocation
+	27, // 14: karhoo.v1.BookingDetails.date_scheduled:type_name -> google.protobuf.Timestamp
+	22, // 15: karhoo.v1.BookingDetails.quote:type_name -> karhoo.v1.BookingDetails.Quote
+	9,  // 16: karhoo.v1.BookingDetails.fare:type_name -> karhoo.v1.Fare
+	23, // 17: karhoo.v1.BookingDetails.fleet:type_name -> karhoo.v1.BookingDetails.Fleet
+	25, // 18: karhoo.v1.BookingDetails.vehicle:type_name -> karhoo.v1.BookingDetails.Vehicle
+	26, // 19: karhoo.v1.BookingDetails.meeting_point:type_name -> karhoo.v1.BookingDetails.MeetingPoint
+	14, // 20: karhoo.v1.CancelBookingResponse.fee:type_name -> karhoo.v1.CancellationFee
+	10, // 21: karhoo.v1.BookingEvent.details:type_name -> karhoo.v1.BookingDetails
+	16, // 22: karhoo.v1.BookingEvent.changes:type_name -> karhoo.v1.FieldChange
+	8,  // 23: karhoo.v1.BookingDetails.Quote.breakdown:type_name -> karhoo.v1.BreakdownItem
+	24, // 24: karhoo.v1.BookingDetails.Vehicle.driver:type_name -> karhoo.v1.BookingDetails.Driver
+	0,  // 25: karhoo.v1.BookingDetails.MeetingPoint.position:type_name -> karhoo.v1.Position
+	2,  // 26: karhoo.v1.Karhoo.GetQuotes:input_type -> karhoo.v1.GetQuotesRequest
+	7,  // 27: karhoo.v1.Karhoo.Book:input_type -> karhoo.v1.BookingRequest
+	11, // 28: karhoo.v1.Karhoo.GetBooking:input_type -> karhoo.v1.GetBookingRequest
+	12, // 29: karhoo.v1.Karhoo.CancelBooking:input_type -> karhoo.v1.CancelBookingRequest
+	15, // 30: karhoo.v1.Karhoo.WatchBooking:input_type -> karhoo.v1.WatchBookingRequest
+	3,  // 31: karhoo.v1.Karhoo.GetQuotes:output_type -> karhoo.v1.QuotesList
+	10, // 32: karhoo.v1.Karhoo.Book:output_type -> karhoo.v1.BookingDetails
+	10, // 33: karhoo.v1.Karhoo.GetBooking:output_type -> karhoo.v1.BookingDetails
+	13, // 34: karhoo.v1.Karhoo.CancelBooking:output_type -> karhoo.v1.CancelBookingResponse
+	17, // 35: karhoo.v1.Karhoo.WatchBooking:output_type -> karhoo.v1.BookingEvent
+	31, // [31:36] is the sub-list for method output_type
+	26, // [26:31] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_karhoo_proto_init() }
+func file_karhoo_proto_init() {
+	if File_karhoo_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_karhoo_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Geolocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuotesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuotesList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Passenger); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Passengers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BreakdownItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBookingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelBookingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelBookingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancellationFee); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchBookingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote_Price); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote_Fleet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote_Vehicle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingDetails_Quote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingDetails_Fleet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingDetails_Driver); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingDetails_Vehicle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_karhoo_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookingDetails_MeetingPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_karhoo_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_karhoo_proto_goTypes,
+		DependencyIndexes: file_karhoo_proto_depIdxs,
+		MessageInfos:      file_karhoo_proto_msgTypes,
+	}.Build()
+	File_karhoo_proto = out.File
+	file_karhoo_proto_rawDesc = nil
+	file_karhoo_proto_goTypes = nil
+	file_karhoo_proto_depIdxs = nil
+}
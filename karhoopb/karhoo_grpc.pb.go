@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package karhoopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// KarhooClient is the client API for Karhoo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KarhooClient interface {
+	// GetQuotes streams the quotes list of a route each time fleets add quotes, until all fleets quoted
+	GetQuotes(ctx context.Context, in *GetQuotesRequest, opts ...grpc.CallOption) (Karhoo_GetQuotesClient, error)
+	// Book books a quote
+	Book(ctx context.Context, in *BookingRequest, opts ...grpc.CallOption) (*BookingDetails, error)
+	// GetBooking gets the details of a booking
+	GetBooking(ctx context.Context, in *GetBookingRequest, opts ...grpc.CallOption) (*BookingDetails, error)
+	// CancelBooking cancels a booking, it fails with FAILED_PRECONDITION if a fee applies and accept_fee is not set
+	CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error)
+	// WatchBooking streams the booking each time it changes, until the trip ends
+	WatchBooking(ctx context.Context, in *WatchBookingRequest, opts ...grpc.CallOption) (Karhoo_WatchBookingClient, error)
+}
+
+type karhooClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKarhooClient(cc grpc.ClientConnInterface) KarhooClient {
+	return &karhooClient{cc}
+}
+
+func (c *karhooClient) GetQuotes(ctx context.Context, in *GetQuotesRequest, opts ...grpc.CallOption) (Karhoo_GetQuotesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Karhoo_ServiceDesc.Streams[0], "/karhoo.v1.Karhoo/GetQuotes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &karhooGetQuotesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Karhoo_GetQuotesClient interface {
+	Recv() (*QuotesList, error)
+	grpc.ClientStream
+}
+
+type karhooGetQuotesClient struct {
+	grpc.ClientStream
+}
+
+func (x *karhooGetQuotesClient) Recv() (*QuotesList, error) {
+	m := new(QuotesList)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *karhooClient) Book(ctx context.Context, in *BookingRequest, opts ...grpc.CallOption) (*BookingDetails, error) {
+	out := new(BookingDetails)
+	err := c.cc.Invoke(ctx, "/karhoo.v1.Karhoo/Book", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *karhooClient) GetBooking(ctx context.Context, in *GetBookingRequest, opts ...grpc.CallOption) (*BookingDetails, error) {
+	out := new(BookingDetails)
+	err := c.cc.Invoke(ctx, "/karhoo.v1.Karhoo/GetBooking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *karhooClient) CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error) {
+	out := new(CancelBookingResponse)
+	err := c.cc.Invoke(ctx, "/karhoo.v1.Karhoo/CancelBooking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *karhooClient) WatchBooking(ctx context.Context, in *WatchBookingRequest, opts ...grpc.CallOption) (Karhoo_WatchBookingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Karhoo_ServiceDesc.Streams[1], "/karhoo.v1.Karhoo/WatchBooking", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &karhooWatchBookingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Karhoo_WatchBookingClient interface {
+	Recv() (*BookingEvent, error)
+	grpc.ClientStream
+}
+
+type karhooWatchBookingClient struct {
+	grpc.ClientStream
+}
+
+func (x *karhooWatchBookingClient) Recv() (*BookingEvent, error) {
+	m := new(BookingEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// KarhooServer is the server API for Karhoo service.
+// All implementations must embed UnimplementedKarhooServer
+// for forward compatibility
+type KarhooServer interface {
+	// GetQuotes streams the quotes list of a route each time fleets add quotes, until all fleets quoted
+	GetQuotes(*GetQuotesRequest, Karhoo_GetQuotesServer) error
+	// Book books a quote
+	Book(context.Context, *BookingRequest) (*BookingDetails, error)
+	// GetBooking gets the details of a booking
+	GetBooking(context.Context, *GetBookingRequest) (*BookingDetails, error)
+	// CancelBooking cancels a booking, it fails with FAILED_PRECONDITION if a fee applies and accept_fee is not set
+	CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error)
+	// WatchBooking streams the booking each time it changes, until the trip ends
+	WatchBooking(*WatchBookingRequest, Karhoo_WatchBookingServer) error
+	mustEmbedUnimplementedKarhooServer()
+}
+
+// UnimplementedKarhooServer must be embedded to have forward compatible implementations.
+type UnimplementedKarhooServer struct {
+}
+
+func (UnimplementedKarhooServer) GetQuotes(*GetQuotesRequest, Karhoo_GetQuotesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetQuotes not implemented")
+}
+func (UnimplementedKarhooServer) Book(context.Context, *BookingRequest) (*BookingDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Book not implemented")
+}
+func (UnimplementedKarhooServer) GetBooking(context.Context, *GetBookingRequest) (*BookingDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBooking not implemented")
+}
+func (UnimplementedKarhooServer) CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBooking not implemented")
+}
+func (UnimplementedKarhooServer) WatchBooking(*WatchBookingRequest, Karhoo_WatchBookingServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBooking not implemented")
+}
+func (UnimplementedKarhooServer) mustEmbedUnimplementedKarhooServer() {}
+
+// UnsafeKarhooServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KarhooServer will
+// result in compilation errors.
+type UnsafeKarhooServer interface {
+	mustEmbedUnimplementedKarhooServer()
+}
+
+func RegisterKarhooServer(s grpc.ServiceRegistrar, srv KarhooServer) {
+	s.RegisterService(&Karhoo_ServiceDesc, srv)
+}
+
+func _Karhoo_GetQuotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetQuotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KarhooServer).GetQuotes(m, &karhooGetQuotesServer{stream})
+}
+
+type Karhoo_GetQuotesServer interface {
+	Send(*QuotesList) error
+	grpc.ServerStream
+}
+
+type karhooGetQuotesServer struct {
+	grpc.ServerStream
+}
+
+func (x *karhooGetQuotesServer) Send(m *QuotesList) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Karhoo_Book_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KarhooServer).Book(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/karhoo.v1.Karhoo/Book",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KarhooServer).Book(ctx, req.(*BookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Karhoo_GetBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KarhooServer).GetBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/karhoo.v1.Karhoo/GetBooking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KarhooServer).GetBooking(ctx, req.(*GetBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Karhoo_CancelBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KarhooServer).CancelBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/karhoo.v1.Karhoo/CancelBooking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KarhooServer).CancelBooking(ctx, req.(*CancelBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Karhoo_WatchBooking_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBookingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KarhooServer).WatchBooking(m, &karhooWatchBookingServer{stream})
+}
+
+type Karhoo_WatchBookingServer interface {
+	Send(*BookingEvent) error
+	grpc.ServerStream
+}
+
+type karhooWatchBookingServer struct {
+	grpc.ServerStream
+}
+
+func (x *karhooWatchBookingServer) Send(m *BookingEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Karhoo_ServiceDesc is the grpc.ServiceDesc for Karhoo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Karhoo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "karhoo.v1.Karhoo",
+	HandlerType: (*KarhooServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Book",
+			Handler:    _Karhoo_Book_Handler,
+		},
+		{
+			MethodName: "GetBooking",
+			Handler:    _Karhoo_GetBooking_Handler,
+		},
+		{
+			MethodName: "CancelBooking",
+			Handler:    _Karhoo_CancelBooking_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetQuotes",
+			Handler:       _Karhoo_GetQuotes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBooking",
+			Handler:       _Karhoo_WatchBooking_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "karhoo.proto",
+}
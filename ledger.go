@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"karhooAPIs.com/util"
+)
+
+// bookingLedger records quote selections, bookings, status changes and cancellations, nil if it could not be opened.
+// Recording failures are reported but never fail the karhoo call they record
+var bookingLedger *util.Ledger
+
+// ledgerPath location of the ledger database, KARHOO_LEDGER overrides the default next to the stored access token
+func ledgerPath() string {
+	if path := os.Getenv("KARHOO_LEDGER"); path != "" {
+		return path
+	}
+	return filepath.Join(util.DataDir(), ".karhoo-ledger.db")
+}
+
+// openLedger opens and migrates the ledger database
+func openLedger(path string) (*util.Ledger, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on")
+	if err != nil {
+		return nil, err
+	}
+	// sqlite serializes writers anyway, a single connection avoids busy errors between our own goroutines
+	db.SetMaxOpenConns(1)
+	l, err := util.NewLedger(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func ledgerWarning(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "warning: recording in the ledger failed:", err)
+	}
+}
+
+// recordQuoteSelection records the quote chosen for a booking
+func recordQuoteSelection(q util.Quote) {
+	if bookingLedger != nil {
+		ledgerWarning(bookingLedger.RecordQuoteSelection(q, time.Now()))
+	}
+}
+
+// recordBooking records a booking response or lookup, quoteID is empty for lookups
+func recordBooking(d *util.BookingDetails, quoteID string) {
+	if bookingLedger != nil {
+		ledgerWarning(bookingLedger.RecordBooking(d, quoteID, time.Now()))
+	}
+}
+
+// recordCancellation records a cancelled booking with the fee charged
+func recordCancellation(bookingID string, reason util.CancelReason, fee *util.CancellationFee) {
+	if bookingLedger != nil {
+		ledgerWarning(bookingLedger.RecordCancellation(bookingID, reason, fee, time.Now()))
+	}
+}
+
+// errNoSuchBooking a booking the caller did not make, reported like a booking that does not exist
+var errNoSuchBooking = util.NewGatewayError(http.StatusNotFound, util.GatewayErrorNotFound, "booking not found")
+
+// errLedgerRequired the gateway and the gRPC server keep the API key client that made each booking in the ledger
+var errLedgerRequired = errors.New("the ledger is unavailable, it keeps which client made each booking")
+
+// recordBookingOwner records the caller in ctx as the owner of a booking it just made
+func recordBookingOwner(ctx context.Context, bookingID string) {
+	if bookingLedger == nil {
+		return
+	}
+	err := bookingLedger.RecordBookingOwner(bookingID, callerClient(ctx), time.Now())
+	if err != nil {
+		util.LogEvent(util.LevelError, "recording the booking owner failed, the client can not access the booking",
+			util.LogField("booking_id", bookingID), util.LogField("error", err))
+	}
+}
+
+// checkBookingOwner checks that the caller in ctx made the booking. Bookings of other clients, and bookings made
+// outside the gateway and gRPC server, are reported as not found
+func checkBookingOwner(ctx context.Context, bookingID string) error {
+	if bookingLedger == nil {
+		return errLedgerRequired
+	}
+	owner, err := bookingLedger.BookingOwner(bookingID)
+	if err != nil {
+		return err
+	}
+	if owner == "" || owner != callerClient(ctx) {
+		return errNoSuchBooking
+	}
+	return nil
+}
+
+func runLedger(args []string) int {
+	fs := newFlagSet("ledger")
+	bookingID := fs.String("booking", "", "show a booking with its history")
+	traveller := fs.String("traveller", "", "list the bookings of a traveller, by full name, email or phone number")
+	date := fs.String("date", "", "list the bookings with a pickup on this day, e.g. 2021-01-08")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	set := 0
+	for _, v := range []string{*bookingID, *traveller, *date} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return usageError(fs, "use exactly one of -booking, -traveller and -date")
+	}
+	if bookingLedger == nil {
+		fmt.Fprintln(os.Stderr, "error: no ledger at", ledgerPath())
+		return exitError
+	}
+
+	if *bookingID != "" {
+		b, err := bookingLedger.Booking(*bookingID)
+		if err != nil {
+			return fail(err)
+		}
+		if b == nil {
+			fmt.Fprintf(os.Stderr, "error: booking %s is not in the ledger\n", *bookingID)
+			return exitError
+		}
+		return printOutput(*output, b, func(w *tabwriter.Writer) {
+			printLedgerBookingTable(w, b)
+		})
+	}
+	var bookings []util.LedgerBooking
+	var err error
+	if *traveller != "" {
+		bookings, err = bookingLedger.BookingsByTraveller(*traveller)
+	} else {
+		day, parseErr := time.ParseInLocation("2006-01-02", *date, time.Local)
+		if parseErr != nil {
+			return usageError(fs, "-date must look like 2021-01-08")
+		}
+		bookings, err = bookingLedger.BookingsScheduledBetween(day, day.AddDate(0, 0, 1))
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return printOutput(*output, bookings, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "BOOKING ID\tTRIP ID\tSCHEDULED\tSTATUS\tTRAVELLER\tFROM\tTO")
+		for _, b := range bookings {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", b.BookingID, b.DisplayTripID, formatLedgerDate(b.DateScheduled),
+				b.Status, b.TravellerName, b.Origin, b.Destination)
+		}
+	})
+}
+
+func printLedgerBookingTable(w *tabwriter.Writer, b *util.LedgerBooking) {
+	fmt.Fprintf(w, "BOOKING ID\t%s\n", b.BookingID)
+	fmt.Fprintf(w, "TRIP ID\t%s\n", b.DisplayTripID)
+	fmt.Fprintf(w, "QUOTE ID\t%s\n", b.QuoteID)
+	fmt.Fprintf(w, "TRAVELLER\t%s %s %s\n", b.TravellerName, b.TravellerPhone, b.TravellerEmail)
+	fmt.Fprintf(w, "SCHEDULED\t%s\n", formatLedgerDate(b.DateScheduled))
+	fmt.Fprintf(w, "ROUTE\t%s -> %s\n", b.Origin, b.Destination)
+	fmt.Fprintf(w, "STATUS\t%s\n", b.Status)
+	for _, s := range b.QuoteSelections {
+		fmt.Fprintf(w, "%s\tselected %s %s, %s\n", formatLedgerDate(s.SelectedAt), s.FleetName, s.VehicleClass,
+			util.FormatPrice(s.PriceHigh, s.Currency))
+	}
+	for _, c := range b.StatusChanges {
+		from := c.From
+		if from == "" {
+			from = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s -> %s\n", formatLedgerDate(c.ChangedAt), from, c.To)
+	}
+	for _, c := range b.Cancellations {
+		fee := "free of charge"
+		if c.Fee > 0 {
+			fee = util.FormatPrice(c.Fee, c.FeeCurrency)
+		}
+		fmt.Fprintf(w, "%s\tcancelled, %s, %s\n", formatLedgerDate(c.CancelledAt), c.Reason, fee)
+	}
+}
+
+func formatLedgerDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"karhooAPIs.com/util"
+)
+
+// lookupGeolocation turns a typed address like "Frankfurt Airport Terminal 1" into a geolocation, using the best
+// address suggestion. near is optional and biases suggestions towards a position
+func lookupGeolocation(a *util.AuthInfo, query string, near *util.Position) (*util.Geolocation, error) {
+	// the same session token ties the autocomplete and place details calls together
+	sessionToken := util.GenerateID()
+	suggestions, err := suggestAddresses(a, query, near, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	if len(suggestions) == 0 {
+		return nil, errors.New("no address found for " + query)
+	}
+	return geolocationOfPlace(a, suggestions[0].PlaceID, sessionToken)
+}
+
+// suggestAddresses returns the address suggestions for a partial address, a new session token is used if it is empty
+func suggestAddresses(a *util.AuthInfo, query string, near *util.Position, sessionToken string) ([]util.AddressSuggestion, error) {
+	if sessionToken == "" {
+		sessionToken = util.GenerateID()
+	}
+	results, err := addressAutocomplete(a, &util.AddressAutocompleteRequest{
+		Query:        query,
+		Position:     near,
+		SessionToken: sessionToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results.Locations, nil
+}
+
+// geolocationOfPlace gets a fully populated geolocation for a place ID of an address suggestion
+func geolocationOfPlace(a *util.AuthInfo, placeID, sessionToken string) (*util.Geolocation, error) {
+	if sessionToken == "" {
+		sessionToken = util.GenerateID()
+	}
+	placeDetails, err := getPlaceDetails(a, placeID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	g := placeDetails.Geolocation()
+	return &g, nil
+}
+
+// geolocationAt gets a fully populated geolocation for the place at a position
+func geolocationAt(a *util.AuthInfo, position util.Position) (*util.Geolocation, error) {
+	placeDetails, err := reverseGeocode(a, position)
+	if err != nil {
+		return nil, err
+	}
+	g := placeDetails.Geolocation()
+	return &g, nil
+}
+
+func runPlace(args []string) int {
+	fs := newFlagSet("place")
+	latitude := fs.Float64("lat", 0, "latitude of the place to look up instead of an address")
+	longitude := fs.Float64("lng", 0, "longitude of the place to look up instead of an address")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	byPosition := *latitude != 0 || *longitude != 0
+	if byPosition == (fs.NArg() == 1) || fs.NArg() > 1 {
+		return usageError(fs, "usage: karhoo place [flags] <address> | -lat <latitude> -lng <longitude>")
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	var g *util.Geolocation
+	if byPosition {
+		position, err := util.NewPosition(*latitude, *longitude)
+		if err != nil {
+			return usageError(fs, err.Error())
+		}
+		g, err = geolocationAt(a, position)
+		if err != nil {
+			return fail(err)
+		}
+	} else {
+		var err error
+		g, err = lookupGeolocation(a, fs.Arg(0), nil)
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return printOutput(*output, g, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "ADDRESS\t%s\n", g.DisplayAddress)
+		fmt.Fprintf(w, "POSITION\t%s\n", g.Position)
+		fmt.Fprintf(w, "PLACE ID\t%s\n", g.PlaceID)
+		fmt.Fprintf(w, "POI TYPE\t%s\n", g.PoiType)
+		fmt.Fprintf(w, "TIMEZONE\t%s\n", g.Timezone)
+	})
+}
@@ -4,131 +4,90 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"karhooAPIs.com/util"
 )
 
-func main() {
-	// ****************************** get access token
-	authInfo, err := getAccessToken()
-	if err != nil {
-		log.Fatal(err)
-	}
-	// ****************************** refresh access token if necessary
-	err = refreshAccessTokenIfExpired(authInfo)
-	if err != nil {
-		log.Fatal(err)
+// circuitBreaker stops calling karhoo endpoints that keep failing, its States are exposed for health checks
+var circuitBreaker = util.NewCircuitBreaker(util.DefaultCircuitBreakerSettings)
+
+// bookingStates status of every booking made or looked up, validates status changes and fires hooks on each of them
+var bookingStates = newBookingStates()
+
+// newBookingStates creates the booking state machine. Bookings are forgotten once they reach a terminal status, no
+// other status can follow, so that long running servers do not keep every booking they ever saw
+func newBookingStates() *util.BookingStateMachine {
+	m := util.NewBookingStateMachine()
+	m.OnTransition(func(t util.BookingTransition) {
+		if t.To.IsTerminal() {
+			m.Forget(t.BookingID)
+		}
+	})
+	return m
+}
+
+// defaultRateLimit stays well below karhoo's rate limits, bursts are smoothed out to 10 calls per second
+var defaultRateLimit = util.RateLimit{Rate: 10, Burst: 20}
+
+// rateLimit budget of karhoo calls, KARHOO_RATE_LIMIT calls per second and KARHOO_RATE_BURST override the defaults.
+// A rate of 0 turns limiting off. The default is returned with the error of an unparsable setting
+func rateLimit() (util.RateLimit, error) {
+	limit := defaultRateLimit
+	if rate := os.Getenv("KARHOO_RATE_LIMIT"); rate != "" {
+		parsed, err := strconv.ParseFloat(rate, 64)
+		if err != nil || parsed < 0 {
+			return defaultRateLimit, fmt.Errorf("KARHOO_RATE_LIMIT %q is not a number of calls per second", rate)
+		}
+		limit.Rate = parsed
 	}
-	// ****************************** register karhoo webhook
-	err = registerWebhook(authInfo, "http://karhoo-webhooks.piizu.com/webhook", util.WebhookSecretKey)
-	if err != nil {
-		log.Fatal(err)
+	if burst := os.Getenv("KARHOO_RATE_BURST"); burst != "" {
+		parsed, err := strconv.Atoi(burst)
+		if err != nil || parsed < 1 {
+			return defaultRateLimit, fmt.Errorf("KARHOO_RATE_BURST %q is not a positive number of calls", burst)
+		}
+		limit.Burst = parsed
 	}
+	return limit, nil
+}
 
-	subscriptions, err := getRegisteredWebhookURLs(authInfo)
+func main() {
+	limit, err := rateLimit()
 	if err != nil {
-		log.Fatal(err)
-	}
-	log.Println("********************* REGISTERED WEBHOOK: ")
-	util.PrintInterface(subscriptions)
-	// ****************************** request quotes
-	origin := util.Geolocation{
-		Latitude:       "50.037933",
-		Longitude:      "8.562152",
-		DisplayAddress: "Frankfurt Airport",
+		fmt.Fprintln(os.Stderr, "warning:", err, "- using", defaultRateLimit.Rate, "calls per second")
 	}
-	destination := util.Geolocation{
-		Latitude:       "51.037933",
-		Longitude:      "8.910231",
-		DisplayAddress: "Some place nearby",
-	}
-	quotesList, err := getQuotes(authInfo, origin, destination, "")
+	util.Limiter = util.NewRateLimiter(util.NewMemoryRateLimitBackend(), &limit)
+	util.Client = &http.Client{Transport: &util.CircuitBreakerTransport{Breaker: circuitBreaker}}
+	level, err := util.ParseLogLevel(os.Getenv("KARHOO_LOG_LEVEL"))
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, "warning: KARHOO_LOG_LEVEL:", err)
 	}
-	// ****************************** retrieve quote list
-	retrievedQuoteList, err := retrieveQuoteList(authInfo, quotesList.ID)
+	util.Log = util.NewStdLogger(nil, level)
+	util.LogRedaction, err = util.ParseRedactionLevel(os.Getenv("KARHOO_LOG_REDACTION"))
 	if err != nil {
-		log.Fatal(err)
-	}
-	if len(retrievedQuoteList.Quotes) == 0 {
-		log.Fatal("failed to request quotes")
-	}
-	log.Println("********************* RETRIEVED QUOTE LIST: ")
-	util.PrintInterface(retrievedQuoteList)
-	// ****************************** aggregate quotes, select the lowest price for quotes with the same vehicle.class
-	vehicleClasses := retrievedQuoteList.Availability.Vehicles.Classes
-	if vehicleClasses == nil {
-		log.Fatal("empty vehicle classes")
-	}
-	type quotePrice struct {
-		QuoteID     string
-		LowestPrice int
-	}
-	lowestPriceQuotes := map[string]quotePrice{}
-	for _, vehicleClass := range vehicleClasses {
-		lowestPriceQuotes[vehicleClass] = quotePrice{
-			QuoteID:     "",
-			LowestPrice: -1,
-		}
-	}
-	for _, quote := range retrievedQuoteList.Quotes {
-		if lowestPriceQuotes[quote.Vehicle.Class].LowestPrice == -1 ||
-			lowestPriceQuotes[quote.Vehicle.Class].LowestPrice > quote.Price.Low {
-			lowestPriceQuotes[quote.Vehicle.Class] = quotePrice{
-				QuoteID:     quote.ID,
-				LowestPrice: quote.Price.Low,
-			}
-		}
+		fmt.Fprintln(os.Stderr, "warning: KARHOO_LOG_REDACTION:", err, "- masking all personal data")
 	}
-	log.Println("********************* LOWEST PRICE QUOTES:")
-	util.PrintInterface(lowestPriceQuotes)
-	// ****************************** select the lowest price quote with some vehicle.class and make a booking
-	vehicleClass := ""
-	quoteIDToBook := ""
-	for k, v := range lowestPriceQuotes {
-		if v.LowestPrice != -1 && v.QuoteID != "" {
-			// choose the first quote with lowest price
-			quoteIDToBook = v.QuoteID
-			vehicleClass = k
-			break
-		}
-	}
-	log.Printf("********************* BOOKING WITH VEHICLE CLASS: %s AND QUOTE ID: %s", vehicleClass, quoteIDToBook)
-	bookingResults, err := bookATrip(authInfo, map[string]interface{}{
-		"quote_id": quoteIDToBook,
-		"passengers": map[string]interface{}{
-			"passenger_details": []map[string]interface{}{{
-				"first_name":   "Chuoxian",
-				"last_name":    "Yang",
-				"phone_number": "+15005550006",
-			}},
-			"luggage": map[string]interface{}{
-				"total": 1,
-			},
-		},
-	})
+	bookingLedger, err = openLedger(ledgerPath())
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, "warning: ledger unavailable, bookings are not recorded:", err)
 	}
-	log.Println("********************* REQUESTED A BOOKING: ")
-	util.PrintInterface(bookingResults)
-	// ****************************** get booking details of previous book request
-	bookingDetails, err := getBookingDetails(authInfo, bookingResults.ID)
+	util.Auditor, err = openAuditLog(auditLogPath())
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, "warning: audit log unavailable, karhoo calls are not recorded:", err)
 	}
-	log.Println("********************* GOT BOOKING DETAILS: ")
-	util.PrintInterface(bookingDetails)
-	// ****************************** cancel booking
-	err = cancelBooking(authInfo, bookingDetails.ID, util.CancelBookingReasons[0])
-	if err != nil {
-		log.Fatal(err)
+	code := runCLI(os.Args[1:])
+	if bookingLedger != nil {
+		bookingLedger.Close()
 	}
-	log.Printf("********************* BOOKING %s CANCELED SUCCESSFULLY", bookingDetails.ID)
+	if util.Auditor != nil {
+		util.Auditor.Close()
+	}
+	os.Exit(code)
 }
 
 func getAccessToken() (*util.AuthInfo, error) {
@@ -136,6 +95,10 @@ func getAccessToken() (*util.AuthInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	return getAccessTokenWithCredentials(cred)
+}
+
+func getAccessTokenWithCredentials(cred *util.Credentials) (*util.AuthInfo, error) {
 	res, err := util.PostRequest(util.GetAccessTokenURL, nil, map[string]interface{}{
 		"username": cred.Username,
 		"password": cred.Password,
@@ -154,6 +117,7 @@ func getAccessToken() (*util.AuthInfo, error) {
 		// calculate expiration time
 		now := time.Now()
 		a.ExpirationTime = now.Add(time.Second * time.Duration(a.ExpiresIn))
+		util.LogEvent(util.LevelInfo, "logged in", util.LogField("expires_in", a.ExpiresIn))
 		return a, nil
 	}
 	// authentication failed with code and error message
@@ -162,7 +126,8 @@ func getAccessToken() (*util.AuthInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	return nil, errors.New(e.Message)
+	util.LogEvent(util.LevelError, "login failed", util.LogField("status", res.StatusCode), util.LogField("code", e.Code))
+	return nil, util.NewAPIError(res.StatusCode, e)
 }
 
 func refreshAccessTokenIfExpired(a *util.AuthInfo) error {
@@ -186,6 +151,7 @@ func refreshAccessTokenIfExpired(a *util.AuthInfo) error {
 			a.AccessToken = r.AccessToken
 			a.ExpiresIn = r.ExpiresIn
 			a.ExpirationTime = time.Now().Add(time.Second * time.Duration(r.ExpiresIn))
+			util.LogEvent(util.LevelInfo, "access token refreshed", append(a.LogFields(), util.LogField("expires_in", r.ExpiresIn))...)
 			return nil
 		}
 		// refresh access token failed with code and error message
@@ -194,16 +160,41 @@ func refreshAccessTokenIfExpired(a *util.AuthInfo) error {
 		if err != nil {
 			return err
 		}
-		return errors.New(e.Message)
+		util.LogEvent(util.LevelError, "refreshing access token failed", append(a.LogFields(),
+			util.LogField("status", res.StatusCode), util.LogField("code", e.Code))...)
+		return util.NewAPIError(res.StatusCode, e)
 	}
 	return nil
 }
 
+// authMutex serializes token refreshes of auth info shared between goroutines
+var authMutex sync.Mutex
+
+// freshAuthInfo refreshes shared auth info if needed and returns a copy of it, safe to use while other goroutines refresh
+func freshAuthInfo(a *util.AuthInfo) (*util.AuthInfo, error) {
+	authMutex.Lock()
+	defer authMutex.Unlock()
+	err := refreshAccessTokenIfExpired(a)
+	if err != nil {
+		return nil, err
+	}
+	fresh := *a
+	return &fresh, nil
+}
+
 func getQuotes(a *util.AuthInfo, origin util.Geolocation, destination util.Geolocation, pickupTime string) (*util.QuotesList, error) {
+	err := origin.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid origin: %w", err)
+	}
+	err = destination.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %w", err)
+	}
 	res, err := util.PostRequest(util.GetQuotesURL, a, map[string]interface{}{
 		"origin":               origin,
 		"destination":          destination,
-		"local_time_of_pickup": "",
+		"local_time_of_pickup": pickupTime,
 	})
 	if err != nil {
 		return nil, err
@@ -224,7 +215,7 @@ func getQuotes(a *util.AuthInfo, origin util.Geolocation, destination util.Geolo
 	if err != nil {
 		return nil, err
 	}
-	return nil, errors.New(e.Message)
+	return nil, util.NewAPIError(res.StatusCode, e)
 }
 
 func retrieveQuoteList(a *util.AuthInfo, quoteListID string) (*util.QuotesList, error) {
@@ -248,11 +239,16 @@ func retrieveQuoteList(a *util.AuthInfo, quoteListID string) (*util.QuotesList,
 	if err != nil {
 		return nil, err
 	}
-	return nil, errors.New(e.Message)
+	return nil, util.NewAPIError(res.StatusCode, e)
 }
 
-func bookATrip(a *util.AuthInfo, params map[string]interface{}) (*util.BookingDetails, error) {
-	res, err := util.PostRequest(util.BookingURL, a, params)
+func bookATrip(a *util.AuthInfo, bookingRequest *util.BookingRequest) (*util.BookingDetails, error) {
+	// fail before any network call if the request is invalid
+	err := bookingRequest.Validate()
+	if err != nil {
+		return nil, err
+	}
+	res, err := util.PostRequest(util.BookingURL, a, bookingRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +260,9 @@ func bookATrip(a *util.AuthInfo, params map[string]interface{}) (*util.BookingDe
 		if err != nil {
 			return nil, err
 		}
+		// a booked quote can not be booked again, nobody should be served it from the cache
+		quoteCache.InvalidateQuote(bookingRequest.QuoteID)
+		recordBooking(bookingResponse, bookingRequest.QuoteID)
 		return bookingResponse, nil
 	}
 	// book trip failed with code and error message
@@ -272,7 +271,11 @@ func bookATrip(a *util.AuthInfo, params map[string]interface{}) (*util.BookingDe
 	if err != nil {
 		return nil, err
 	}
-	return nil, errors.New(e.Message)
+	apiError := util.NewAPIError(res.StatusCode, e)
+	if util.IsQuoteExpired(apiError) {
+		quoteCache.InvalidateQuote(bookingRequest.QuoteID)
+	}
+	return nil, apiError
 }
 
 func getBookingDetails(a *util.AuthInfo, bookingID string) (*util.BookingDetails, error) {
@@ -288,6 +291,7 @@ func getBookingDetails(a *util.AuthInfo, bookingID string) (*util.BookingDetails
 		if err != nil {
 			return nil, err
 		}
+		recordBooking(bookingDetails, "")
 		return bookingDetails, nil
 	}
 	// get booking details failed with code and error message
@@ -296,10 +300,13 @@ func getBookingDetails(a *util.AuthInfo, bookingID string) (*util.BookingDetails
 	if err != nil {
 		return nil, err
 	}
-	return nil, errors.New(e.Message)
+	return nil, util.NewAPIError(res.StatusCode, e)
 }
 
-func cancelBooking(a *util.AuthInfo, bookingID, cancelReason string) error {
+func cancelBooking(a *util.AuthInfo, bookingID string, cancelReason util.CancelReason) error {
+	if !cancelReason.Valid() {
+		return fmt.Errorf("unknown cancel reason %q", cancelReason)
+	}
 	cancelBookingURL := fmt.Sprintf(util.CancelBookingURL, bookingID)
 	res, err := util.PostRequest(cancelBookingURL, a, map[string]interface{}{
 		"reason": cancelReason,
@@ -307,18 +314,66 @@ func cancelBooking(a *util.AuthInfo, bookingID, cancelReason string) error {
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 	if res.StatusCode == http.StatusNoContent {
 		return nil
 	}
 	// cancel booking failed with code and error message
 	decoder := json.NewDecoder(res.Body)
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return err
+	}
+	return util.NewAPIError(res.StatusCode, e)
+}
+
+func getCancellationFee(a *util.AuthInfo, bookingID string) (*util.CancellationFee, error) {
+	res, err := util.GetRequest(fmt.Sprintf(util.CancellationFeeURL, bookingID), a)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(res.Body)
 	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var fee *util.CancellationFee
+		err = decoder.Decode(&fee)
+		if err != nil {
+			return nil, err
+		}
+		return fee, nil
+	}
+	// get cancellation fee failed with code and error message
 	var e *util.ErrorInfo
 	err = decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+// errCancellationNotConfirmed the booking was not cancelled because the caller did not accept the cancellation fee
+var errCancellationNotConfirmed = errors.New("cancellation fee not confirmed, booking was not cancelled")
+
+// cancelBookingWithFeeCheck previews the cancellation fee and only cancels if it is free or confirm accepts the fee
+func cancelBookingWithFeeCheck(a *util.AuthInfo, bookingID string, cancelReason util.CancelReason,
+	confirm func(fee *util.CancellationFee) bool) error {
+	if !cancelReason.Valid() {
+		return fmt.Errorf("unknown cancel reason %q", cancelReason)
+	}
+	fee, err := getCancellationFee(a, bookingID)
 	if err != nil {
 		return err
 	}
-	return errors.New(e.Message)
+	if fee.Applies() && (confirm == nil || !confirm(fee)) {
+		return errCancellationNotConfirmed
+	}
+	err = cancelBooking(a, bookingID, cancelReason)
+	if err != nil {
+		return err
+	}
+	recordCancellation(bookingID, cancelReason, fee)
+	return nil
 }
 
 func registerWebhook(a *util.AuthInfo, url, sharedSecret string) error {
@@ -330,6 +385,7 @@ func registerWebhook(a *util.AuthInfo, url, sharedSecret string) error {
 		return err
 	}
 	if res.StatusCode == http.StatusCreated {
+		util.LogEvent(util.LevelInfo, "webhook registered", append(a.LogFields(), util.LogField("url", url))...)
 		return nil
 	}
 	// register webhook failed with code and error message
@@ -340,7 +396,9 @@ func registerWebhook(a *util.AuthInfo, url, sharedSecret string) error {
 	if err != nil {
 		return err
 	}
-	return errors.New(e.Message)
+	util.LogEvent(util.LevelError, "registering webhook failed", append(a.LogFields(), util.LogField("url", url),
+		util.LogField("status", res.StatusCode), util.LogField("code", e.Code))...)
+	return util.NewAPIError(res.StatusCode, e)
 }
 
 func getRegisteredWebhookURLs(a *util.AuthInfo) (*util.WebhookSubscription, error) {
@@ -356,6 +414,7 @@ func getRegisteredWebhookURLs(a *util.AuthInfo) (*util.WebhookSubscription, erro
 		if err != nil {
 			return nil, err
 		}
+		util.LogEvent(util.LevelDebug, "webhook subscription", append(a.LogFields(), util.LogField("url", sub.URL))...)
 		return sub, nil
 	}
 	// get registered webhook urls failed with code and error message
@@ -364,5 +423,202 @@ func getRegisteredWebhookURLs(a *util.AuthInfo) (*util.WebhookSubscription, erro
 	if err != nil {
 		return nil, err
 	}
-	return nil, errors.New(e.Message)
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+func searchBookings(a *util.AuthInfo, search *util.BookingSearch) (*util.BookingSearchResults, error) {
+	res, err := util.PostRequest(util.SearchBookingsURL, a, search)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var results *util.BookingSearchResults
+		err = decoder.Decode(&results)
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	// search bookings failed with code and error message
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+func trackDriver(a *util.AuthInfo, bookingID string) (*util.DriverTracking, error) {
+	return getDriverTracking(fmt.Sprintf(util.TrackDriverURL, bookingID), a)
+}
+
+// trackDriverByFollowCode tracks the driver with the follow code shared with passengers, no authentication needed
+func trackDriverByFollowCode(followCode string) (*util.DriverTracking, error) {
+	return getDriverTracking(fmt.Sprintf(util.TrackDriverByFollowCodeURL, followCode), nil)
+}
+
+func getDriverTracking(url string, a *util.AuthInfo) (*util.DriverTracking, error) {
+	res, err := util.GetRequest(url, a)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var tracking *util.DriverTracking
+		err = decoder.Decode(&tracking)
+		if err != nil {
+			return nil, err
+		}
+		return tracking, nil
+	}
+	// track driver failed with code and error message
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+// getBookingDetailsByFollowCode gets booking details with the follow code shared with passengers, no authentication needed
+func getBookingDetailsByFollowCode(followCode string) (*util.BookingDetails, error) {
+	res, err := util.GetRequest(fmt.Sprintf(util.FollowBookingURL, followCode), nil)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var bookingDetails *util.BookingDetails
+		err = decoder.Decode(&bookingDetails)
+		if err != nil {
+			return nil, err
+		}
+		return bookingDetails, nil
+	}
+	// get booking details by follow code failed with code and error message
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+func getFare(a *util.AuthInfo, bookingID string) (*util.FareResponse, error) {
+	res, err := util.GetRequest(fmt.Sprintf(util.FareURL, bookingID), a)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var fare *util.FareResponse
+		err = decoder.Decode(&fare)
+		if err != nil {
+			return nil, err
+		}
+		return fare, nil
+	}
+	// get fare failed with code and error message
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+func addressAutocomplete(a *util.AuthInfo, autocompleteRequest *util.AddressAutocompleteRequest) (*util.AddressAutocompleteResults, error) {
+	res, err := util.PostRequest(util.AddressAutocompleteURL, a, autocompleteRequest)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var results *util.AddressAutocompleteResults
+		err = decoder.Decode(&results)
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	// address autocomplete failed with code and error message
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+func getPlaceDetails(a *util.AuthInfo, placeID, sessionToken string) (*util.PlaceDetails, error) {
+	res, err := util.PostRequest(util.PlaceDetailsURL, a, map[string]interface{}{
+		"place_id":      placeID,
+		"session_token": sessionToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodePlaceDetails(res)
+}
+
+func reverseGeocode(a *util.AuthInfo, position util.Position) (*util.PlaceDetails, error) {
+	res, err := util.GetRequest(fmt.Sprintf(util.ReverseGeocodeURL, position.Latitude, position.Longitude), a)
+	if err != nil {
+		return nil, err
+	}
+	return decodePlaceDetails(res)
+}
+
+func decodePlaceDetails(res *http.Response) (*util.PlaceDetails, error) {
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var placeDetails *util.PlaceDetails
+		err := decoder.Decode(&placeDetails)
+		if err != nil {
+			return nil, err
+		}
+		return placeDetails, nil
+	}
+	// get place details failed with code and error message
+	var e *util.ErrorInfo
+	err := decoder.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return nil, util.NewAPIError(res.StatusCode, e)
+}
+
+func checkCoverage(a *util.AuthInfo, position util.Position, pickupTime string) (bool, error) {
+	coverageURL := fmt.Sprintf(util.QuotesCoverageURL,
+		strconv.FormatFloat(position.Latitude, 'f', util.DefaultCoordinatePrecision, 64),
+		strconv.FormatFloat(position.Longitude, 'f', util.DefaultCoordinatePrecision, 64),
+		url.QueryEscape(pickupTime))
+	res, err := util.GetRequest(coverageURL, a)
+	if err != nil {
+		return false, err
+	}
+	decoder := json.NewDecoder(res.Body)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		var coverage *util.CoverageResponse
+		err = decoder.Decode(&coverage)
+		if err != nil {
+			return false, err
+		}
+		return coverage.Coverage, nil
+	}
+	// check coverage failed with code and error message
+	var e *util.ErrorInfo
+	err = decoder.Decode(&e)
+	if err != nil {
+		return false, err
+	}
+	return false, util.NewAPIError(res.StatusCode, e)
 }
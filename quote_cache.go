@@ -0,0 +1,31 @@
+package main
+
+import (
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// quoteCache quotes lists of recently quoted routes, coordinates within about 10 meters share an entry
+var quoteCache = util.NewQuoteCache(util.NewMemoryQuoteCacheBackend(), 4)
+
+// getQuotesCached returns the completed quotes list of a route, served from the cache while its validity lasts so
+// that users asking for the same route seconds apart do not each trigger getQuotes
+func getQuotesCached(a *util.AuthInfo, origin, destination util.Geolocation, pickupTime string) (*util.QuotesList, error) {
+	if cached, ok := quoteCache.Get(origin, destination, pickupTime, time.Now()); ok {
+		return cached, nil
+	}
+	quotesList, err := getQuotes(a, origin, destination, pickupTime)
+	if err != nil {
+		return nil, err
+	}
+	retrievedQuoteList, err := pollQuoteList(a, quotesList.ID, defaultBatchOptions.PollInterval, defaultBatchOptions.PollTimeout, func() {})
+	if err != nil {
+		return nil, err
+	}
+	// a list retrieved before all fleets quoted would hide the missing quotes for its whole validity
+	if retrievedQuoteList.Status == util.QuotesListCompleted {
+		quoteCache.Set(origin, destination, pickupTime, retrievedQuoteList, time.Now())
+	}
+	return retrievedQuoteList, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"karhooAPIs.com/util"
+)
+
+// bookWithRequote books a quote and, if it expired in the meantime, re-quotes the same route and books the equivalent
+// quote instead. The price change is returned when the booking was made with a new quote, nil otherwise. The quote
+// booked in the end is recorded as selected
+func bookWithRequote(a *util.AuthInfo, bookingRequest *util.BookingRequest, quote util.Quote,
+	origin, destination util.Geolocation, pickupTime string, tolerance util.FareTolerance) (*util.BookingDetails, *util.PriceChange, error) {
+	bookingDetails, err := bookATrip(a, bookingRequest)
+	if err == nil {
+		recordQuoteSelection(quote)
+		return bookingDetails, nil, nil
+	}
+	if !util.IsQuoteExpired(err) {
+		return nil, nil, err
+	}
+
+	// bookATrip invalidated the cached quotes list of the expired quote, so this gets fresh quotes
+	quotesList, err := getQuotesCached(a, origin, destination, pickupTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	equivalent, err := util.FindEquivalentQuote(quote, quotesList.Quotes, tolerance)
+	if err != nil {
+		return nil, nil, err
+	}
+	util.LogEvent(util.LevelWarn, "quote expired, retrying with an equivalent quote", append(a.LogFields(),
+		util.LogField("quote_id", bookingRequest.QuoteID), util.LogField("equivalent_quote_id", equivalent.ID))...)
+	retry := *bookingRequest
+	retry.QuoteID = equivalent.ID
+	// passengers and flight or train numbers have to fit the new quote as well
+	err = retry.ValidateForQuote(*equivalent)
+	if err != nil {
+		return nil, nil, err
+	}
+	bookingDetails, err = bookATrip(a, &retry)
+	if err != nil {
+		return nil, nil, err
+	}
+	recordQuoteSelection(*equivalent)
+	return bookingDetails, util.NewPriceChange(quote, *equivalent), nil
+}
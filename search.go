@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// newBookingIterator creates an iterator over the bookings matching search, starting at its pagination offset
+func newBookingIterator(a *util.AuthInfo, search util.BookingSearch) *util.BookingIterator {
+	return util.NewBookingIterator(search, func(s *util.BookingSearch) (*util.BookingSearchResults, error) {
+		return searchBookings(a, s)
+	})
+}
+
+// listBookings collects all bookings matching search
+func listBookings(a *util.AuthInfo, search util.BookingSearch) ([]util.BookingDetails, error) {
+	bookings := []util.BookingDetails{}
+	it := newBookingIterator(a, search)
+	for it.Next() {
+		bookings = append(bookings, *it.Booking())
+	}
+	return bookings, it.Err()
+}
+
+func runSearch(args []string) int {
+	fs := newFlagSet("search")
+	statuses := fs.String("status", "", "comma separated booking statuses, e.g. CONFIRMED,DRIVER_EN_ROUTE")
+	date := fs.String("date", "", "only bookings with a pickup on this day, e.g. 2021-01-08")
+	traveller := fs.String("traveller", "", "partner traveller ID")
+	costCenter := fs.String("cost-center", "", "cost center reference")
+	organisation := fs.String("organisation", "", "organisation ID")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	search := util.BookingSearch{PartnerTravellerID: *traveller, CostCenterReference: *costCenter, OrganisationID: *organisation}
+	if *statuses != "" {
+		for _, s := range strings.Split(*statuses, ",") {
+			status := util.BookingStatus(strings.ToUpper(strings.TrimSpace(s)))
+			if !status.Valid() {
+				return usageError(fs, "unknown booking status %q", s)
+			}
+			search.Statuses = append(search.Statuses, status)
+		}
+	}
+	if *date != "" {
+		day, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+		if err != nil {
+			return usageError(fs, "-date must look like 2021-01-08")
+		}
+		search.ScheduledOn(day)
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	bookings, err := listBookings(a, search)
+	if err != nil {
+		return fail(err)
+	}
+	return printOutput(*output, bookings, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "BOOKING ID\tTRIP ID\tSCHEDULED\tSTATUS\tFROM\tTO")
+		for _, b := range bookings {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", b.ID, b.DisplayTripID, formatLedgerDate(b.DateScheduled), b.Status,
+				b.Origin.DisplayAddress, b.Destination.DisplayAddress)
+		}
+	})
+}
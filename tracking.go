@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// streamDriverTracking pushes the driver position and ETAs of a booking on the returned channel until the trip ends
+// or ctx is cancelled, the channel is closed afterwards
+func streamDriverTracking(ctx context.Context, a *util.AuthInfo, bookingID string, interval time.Duration) <-chan util.TrackingUpdate {
+	return util.StreamTracking(ctx, bookingID, interval,
+		func() (*util.BookingDetails, error) {
+			fresh, err := freshAuthInfo(a)
+			if err != nil {
+				return nil, err
+			}
+			return getBookingDetails(fresh, bookingID)
+		},
+		func() (*util.DriverTracking, error) {
+			fresh, err := freshAuthInfo(a)
+			if err != nil {
+				return nil, err
+			}
+			return trackDriver(fresh, bookingID)
+		})
+}
+
+// streamDriverTrackingByFollowCode same as streamDriverTracking for passengers who only know the follow code
+func streamDriverTrackingByFollowCode(ctx context.Context, followCode string, interval time.Duration) <-chan util.TrackingUpdate {
+	return util.StreamTracking(ctx, "", interval,
+		func() (*util.BookingDetails, error) {
+			return getBookingDetailsByFollowCode(followCode)
+		},
+		func() (*util.DriverTracking, error) {
+			return trackDriverByFollowCode(followCode)
+		})
+}
+
+func runTrack(args []string) int {
+	fs := newFlagSet("track")
+	followCode := fs.String("follow-code", "", "track with the follow code shared with passengers instead of a booking ID")
+	interval := fs.Duration("interval", util.DefaultTrackingInterval, "how often to poll karhoo")
+	output := outputFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if !(*followCode == "" && fs.NArg() == 1) && !(*followCode != "" && fs.NArg() == 0) {
+		return usageError(fs, "usage: karhoo track [flags] <booking-id>, or karhoo track -follow-code <code>")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		<-interrupt
+		cancel()
+	}()
+
+	var updates <-chan util.TrackingUpdate
+	if *followCode != "" {
+		updates = streamDriverTrackingByFollowCode(ctx, *followCode, *interval)
+	} else {
+		a, code := cliAuthInfo()
+		if code != exitOK {
+			return code
+		}
+		updates = streamDriverTracking(ctx, a, fs.Arg(0), *interval)
+	}
+	code := exitOK
+	for update := range updates {
+		if update.Err != nil {
+			// karhoo being unreachable or failing passes, a wrong booking ID or follow code does not
+			if !transientPollError(update.Err) {
+				return fail(update.Err)
+			}
+			fmt.Fprintln(os.Stderr, "warning: tracking failed, retrying:", update.Err)
+			continue
+		}
+		code = printOutput(*output, update, func(w *tabwriter.Writer) {
+			if update.Tracking == nil {
+				fmt.Fprintf(w, "%s\t%s\n", update.At.Format(time.RFC3339), update.Status)
+				return
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\tpickup in %d min\tarrival in %d min\n", update.At.Format(time.RFC3339),
+				update.Status, update.Tracking.Position, update.Tracking.OriginETA, update.Tracking.DestinationETA)
+		})
+	}
+	return code
+}
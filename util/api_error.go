@@ -0,0 +1,60 @@
+package util
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// APIError a failed karhoo call, carrying the http status code and the decoded error response
+type APIError struct {
+	StatusCode int
+	ErrorInfo
+}
+
+// NewAPIError creates an APIError from a decoded error response, e may be nil if karhoo sent no error details
+func NewAPIError(statusCode int, e *ErrorInfo) *APIError {
+	apiError := &APIError{StatusCode: statusCode}
+	if e != nil {
+		apiError.ErrorInfo = *e
+	}
+	if apiError.Message == "" {
+		apiError.Message = http.StatusText(statusCode)
+	}
+	return apiError
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// TransportError a karhoo call that got no response, e.g. because the connection failed or timed out
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// IsQuoteExpired checks if a booking failed because its quote is no longer valid. Karhoo reports it with a message
+// saying the quote expired or is no longer available
+func IsQuoteExpired(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	if apiError.StatusCode == http.StatusGone {
+		return true
+	}
+	message := strings.ToLower(apiError.Message)
+	for _, detail := range apiError.Details {
+		message += " " + strings.ToLower(detail.Message+" "+detail.Detail)
+	}
+	return strings.Contains(message, "quote") &&
+		(strings.Contains(message, "expired") || strings.Contains(message, "no longer"))
+}
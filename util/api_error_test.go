@@ -0,0 +1,14 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestIsQuoteExpired(t *testing.T) {
+	if !IsQuoteExpired(NewAPIError(400, &ErrorInfo{Message: "Quote has expired"})) {
+		t.Error("expected expired quote error to be detected")
+	}
+	if IsQuoteExpired(NewAPIError(400, &ErrorInfo{Message: "Invalid phone number"})) {
+		t.Error("expected other errors not to be detected as expired quote")
+	}
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+// TravelPolicy limits a ride has to respect to be booked without approval
+type TravelPolicy struct {
+	// MaxPrice highest acceptable quote price in the smallest currency unit, 0 means no limit
+	MaxPrice int `json:"max_price"`
+	// CurrencyCode currency MaxPrice is expressed in, empty means any currency
+	CurrencyCode string `json:"currency_code"`
+	// AllowedVehicleClasses vehicle classes that can be booked, empty means all classes
+	AllowedVehicleClasses []string `json:"allowed_vehicle_classes"`
+}
+
+// Violations returns the reasons why a quote exceeds the policy, empty if the quote is within policy
+func (p TravelPolicy) Violations(q Quote) []string {
+	violations := []string{}
+	if p.CurrencyCode != "" && q.Price.CurrencyCode != p.CurrencyCode {
+		violations = append(violations, fmt.Sprintf("currency %s is not %s", q.Price.CurrencyCode, p.CurrencyCode))
+	}
+	if p.MaxPrice > 0 && q.Price.High > p.MaxPrice {
+		violations = append(violations, fmt.Sprintf("price %d exceeds maximum %d", q.Price.High, p.MaxPrice))
+	}
+	if len(p.AllowedVehicleClasses) > 0 {
+		allowed := false
+		for _, class := range p.AllowedVehicleClasses {
+			if class == q.Vehicle.Class {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("vehicle class %s is not allowed", q.Vehicle.Class))
+		}
+	}
+	return violations
+}
+
+// ApprovalStatus state of a booking waiting for approval
+type ApprovalStatus string
+
+const (
+	// ApprovalPending booking is parked until someone approves or rejects it
+	ApprovalPending ApprovalStatus = "PENDING"
+	// ApprovalInProgress booking is being approved or rejected
+	ApprovalInProgress ApprovalStatus = "IN_PROGRESS"
+	// ApprovalApproved booking was approved and booked
+	ApprovalApproved ApprovalStatus = "APPROVED"
+	// ApprovalRejected booking was rejected
+	ApprovalRejected ApprovalStatus = "REJECTED"
+	// ApprovalExpired quote lapsed and no equivalent quote could be found when re-quoting
+	ApprovalExpired ApprovalStatus = "EXPIRED"
+)
+
+// ApprovalRequest an out of policy booking parked for approval
+type ApprovalRequest struct {
+	ID     string         `json:"id"`
+	Status ApprovalStatus `json:"status"`
+	// Client API key client that requested the booking, empty for requests made on the command line
+	Client         string          `json:"client,omitempty"`
+	QuoteID        string          `json:"quote_id"`
+	FleetID        string          `json:"fleet_id"`
+	FleetName      string          `json:"fleet_name"`
+	VehicleClass   string          `json:"vehicle_class"`
+	Price          int             `json:"price"`
+	CurrencyCode   string          `json:"currency_code"`
+	Violations     []string        `json:"violations"`
+	Policy         TravelPolicy    `json:"policy"`
+	Origin         Geolocation     `json:"origin"`
+	Destination    Geolocation     `json:"destination"`
+	PickupTime     string          `json:"pickup_time"`
+	BookingRequest *BookingRequest `json:"booking_request"`
+	CreatedAt      time.Time       `json:"created_at"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	DecidedBy      string          `json:"decided_by"`
+	DecidedAt      time.Time       `json:"decided_at"`
+	Reason         string          `json:"reason"`
+	BookingID      string          `json:"booking_id"`
+}
+
+// Expired checks if the quote of the approval request is no longer valid
+func (r *ApprovalRequest) Expired(now time.Time) bool {
+	return !r.ExpiresAt.After(now)
+}
+
+// ApprovedQuote the quote the approver saw, as far as the request keeps it, to find an equivalent quote once it expired
+func (r *ApprovalRequest) ApprovedQuote() Quote {
+	var q Quote
+	q.ID = r.QuoteID
+	q.Fleet.ID = r.FleetID
+	q.Fleet.Name = r.FleetName
+	q.Vehicle.Class = r.VehicleClass
+	q.Price.High = r.Price
+	q.Price.CurrencyCode = r.CurrencyCode
+	return q
+}
+
+// Requote replaces the expired quote of the request by q. It returns whether the request has to be approved again
+// because the price changed or q breaks the policy in a way that was not approved
+func (r *ApprovalRequest) Requote(q Quote, expiresAt time.Time) bool {
+	violations := r.Policy.Violations(q)
+	approved := map[string]bool{}
+	for _, v := range r.Violations {
+		approved[v] = true
+	}
+	reapprove := q.Price.High != r.Price || q.Price.CurrencyCode != r.CurrencyCode
+	for _, v := range violations {
+		if !approved[v] {
+			reapprove = true
+		}
+	}
+	r.QuoteID = q.ID
+	r.FleetID = q.Fleet.ID
+	r.FleetName = q.Fleet.Name
+	r.Price = q.Price.High
+	r.CurrencyCode = q.Price.CurrencyCode
+	r.Violations = violations
+	r.ExpiresAt = expiresAt
+	return reapprove
+}
+
+// ApprovalNotifier called whenever an approval request is created or changes status
+type ApprovalNotifier func(r *ApprovalRequest)
+
+// QuoteExpiration calculates when quotes of a quotes list stop being valid, validity is in seconds
+func QuoteExpiration(retrievedAt time.Time, validity int) time.Time {
+	return retrievedAt.Add(time.Second * time.Duration(validity))
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTravelPolicyViolations(t *testing.T) {
+	var q Quote
+	q.Price.CurrencyCode = "EUR"
+	q.Price.High = 5000
+	q.Vehicle.Class = "Exec"
+
+	policy := TravelPolicy{MaxPrice: 6000, CurrencyCode: "EUR", AllowedVehicleClasses: []string{"Saloon", "Exec"}}
+	if violations := policy.Violations(q); len(violations) != 0 {
+		t.Errorf("expected quote within policy, got %v", violations)
+	}
+	policy = TravelPolicy{MaxPrice: 4000, AllowedVehicleClasses: []string{"Saloon"}}
+	if violations := policy.Violations(q); len(violations) != 2 {
+		t.Errorf("expected 2 violations, got %v", violations)
+	}
+}
+
+func TestApprovalRequestExpired(t *testing.T) {
+	now := time.Now()
+	r := &ApprovalRequest{ExpiresAt: QuoteExpiration(now, 300)}
+	if r.Expired(now) {
+		t.Error("approval request should not be expired yet")
+	}
+	if !r.Expired(now.Add(time.Minute * 5)) {
+		t.Error("approval request should be expired")
+	}
+}
+
+func TestApprovalRequestRequote(t *testing.T) {
+	policy := TravelPolicy{MaxPrice: 4000, AllowedVehicleClasses: []string{"Saloon"}}
+	var q Quote
+	q.ID = "list-1:quote-1"
+	q.Fleet.ID = "fleet-1"
+	q.Vehicle.Class = "Saloon"
+	q.Price.High, q.Price.CurrencyCode = 5000, "EUR"
+	r := &ApprovalRequest{QuoteID: q.ID, FleetID: q.Fleet.ID, VehicleClass: q.Vehicle.Class, Price: q.Price.High,
+		CurrencyCode: q.Price.CurrencyCode, Violations: policy.Violations(q), Policy: policy}
+	if approved := r.ApprovedQuote(); approved.Price.High != 5000 || approved.Fleet.ID != "fleet-1" {
+		t.Errorf("expected the approved quote, got %+v", approved)
+	}
+
+	now := time.Now()
+	q.ID = "list-2:quote-1"
+	if r.Requote(q, now) {
+		t.Error("expected a re-quote at the approved price to need no new approval")
+	}
+	if r.QuoteID != "list-2:quote-1" || !r.ExpiresAt.Equal(now) {
+		t.Errorf("expected the request to use the new quote, got %+v", r)
+	}
+
+	q.ID = "list-3:quote-1"
+	q.Price.High = 5400
+	if !r.Requote(q, now) {
+		t.Error("expected a changed price to need a new approval")
+	}
+	if r.Price != 5400 || len(r.Violations) != 1 {
+		t.Errorf("expected the new price and its violations, got %+v", r)
+	}
+}
@@ -0,0 +1,279 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry one karhoo call in the audit log. Hash covers every other field and the hash of the previous entry, so
+// changing, removing or reordering entries breaks the chain
+type AuditEntry struct {
+	Seq        int64     `json:"seq"`
+	Time       time.Time `json:"time"`
+	Endpoint   string    `json:"endpoint"`
+	URL        string    `json:"url"`
+	Actor      string    `json:"actor"`
+	BookingID  string    `json:"booking_id,omitempty"`
+	QuoteID    string    `json:"quote_id,omitempty"`
+	StatusCode int       `json:"status_code"`
+	// LatencyMs time until the response headers arrived
+	LatencyMs int64 `json:"latency_ms"`
+	// Error transport error, empty if karhoo answered
+	Error string `json:"error,omitempty"`
+	// Payload request body with personal data and secrets masked
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+}
+
+// computeHash hashes the entry with an empty Hash field
+func (e AuditEntry) computeHash() (string, error) {
+	e.Hash = ""
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AuditLog append-only log of karhoo calls, one json entry per line. Appends hold an exclusive lock on the file and
+// continue the chain from its last entry, so several processes can share one log
+type AuditLog struct {
+	// Actor recorded for calls made without auth info or whose auth info names no actor
+	Actor string
+
+	mutex    sync.Mutex
+	file     *os.File
+	seq      int64
+	lastHash string
+}
+
+// Auditor audit log every PostRequest and GetRequest is recorded in, nil means calls are not recorded
+var Auditor *AuditLog
+
+// OpenAuditLog opens the audit log at path for appending, creating it if needed. The chain continues from the last
+// entry of an existing log
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l := &AuditLog{file: f}
+	err = lockFile(f)
+	if err == nil {
+		err = l.readTail()
+		unlockFile(f)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// readTail reads seq and hash of the last entry in the file, which another process may have appended
+func (l *AuditLog) readTail() error {
+	last, err := lastLine(l.file)
+	if err != nil {
+		return err
+	}
+	if last == nil {
+		l.seq, l.lastHash = 0, ""
+		return nil
+	}
+	var e AuditEntry
+	err = json.Unmarshal(last, &e)
+	if err != nil {
+		return fmt.Errorf("last audit entry unreadable: %w", err)
+	}
+	l.seq, l.lastHash = e.Seq, e.Hash
+	return nil
+}
+
+// lastLine reads the last non-empty line of f backwards from its end, nil if f has none
+func lastLine(f *os.File) ([]byte, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	end := info.Size()
+	var line []byte
+	chunk := make([]byte, 4096)
+	for offset := end; offset > 0; {
+		n := int64(len(chunk))
+		if offset < n {
+			n = offset
+		}
+		offset -= n
+		_, err = f.ReadAt(chunk[:n], offset)
+		if err != nil {
+			return nil, err
+		}
+		line = append(append([]byte{}, chunk[:n]...), line...)
+		trimmed := bytes.TrimRight(line, " \t\r\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return bytes.TrimSpace(trimmed[i+1:]), nil
+		}
+	}
+	if line = bytes.TrimSpace(line); len(line) > 0 {
+		return line, nil
+	}
+	return nil, nil
+}
+
+// Append chains the entry to the log and writes it, Seq, PrevHash and Hash are set by Append
+func (l *AuditLog) Append(e AuditEntry) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if len(e.Payload) > 0 {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, e.Payload); err != nil {
+			return err
+		}
+		e.Payload = compact.Bytes()
+	}
+	err := lockFile(l.file)
+	if err != nil {
+		return err
+	}
+	defer unlockFile(l.file)
+	err = l.readTail()
+	if err != nil {
+		return err
+	}
+	e.Seq = l.seq + 1
+	e.Time = e.Time.UTC()
+	e.PrevHash = l.lastHash
+	hash, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = l.file.Write(append(b, '\n'))
+	if err != nil {
+		return err
+	}
+	l.seq, l.lastHash = e.Seq, e.Hash
+	return nil
+}
+
+// LastHash hash of the last entry, keep it outside the log to also detect entries cut off at the end
+func (l *AuditLog) LastHash() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.lastHash
+}
+
+// Close closes the log file
+func (l *AuditLog) Close() error {
+	return l.file.Close()
+}
+
+// AuditChainError the first entry of an audit log that does not fit the chain
+type AuditChainError struct {
+	Line   int
+	Seq    int64
+	Reason string
+}
+
+func (e *AuditChainError) Error() string {
+	return fmt.Sprintf("audit log broken at line %d (seq %d): %s", e.Line, e.Seq, e.Reason)
+}
+
+// AuditVerification result of verifying an audit log
+type AuditVerification struct {
+	Entries  int64
+	LastHash string
+}
+
+// VerifyAuditLog checks the hash chain of an audit log, returning an *AuditChainError for the first entry that was
+// changed, removed or inserted
+func VerifyAuditLog(r io.Reader) (*AuditVerification, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	v := &AuditVerification{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e AuditEntry
+		err := json.Unmarshal(scanner.Bytes(), &e)
+		if err != nil {
+			return v, &AuditChainError{Line: line, Seq: v.Entries + 1, Reason: "unreadable entry: " + err.Error()}
+		}
+		if e.Seq != v.Entries+1 {
+			return v, &AuditChainError{Line: line, Seq: e.Seq, Reason: fmt.Sprintf("expected seq %d", v.Entries+1)}
+		}
+		if e.PrevHash != v.LastHash {
+			return v, &AuditChainError{Line: line, Seq: e.Seq, Reason: "previous hash does not match"}
+		}
+		hash, err := e.computeHash()
+		if err != nil {
+			return v, err
+		}
+		if hash != e.Hash {
+			return v, &AuditChainError{Line: line, Seq: e.Seq, Reason: "hash does not match the entry"}
+		}
+		v.Entries = e.Seq
+		v.LastHash = e.Hash
+	}
+	return v, scanner.Err()
+}
+
+// AuditIDs finds the booking and quote IDs a call is about in its URL, request payload and response body
+func AuditIDs(method, rawURL string, payload, response []byte) (bookingID, quoteID string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		next := segments[i+1]
+		switch {
+		case segments[i] == "bookings" && next != "search" && next != "follow":
+			bookingID = next
+		case segments[i] == "quotes" && next != "coverage":
+			quoteID = next
+		}
+	}
+	var request struct {
+		QuoteID string `json:"quote_id"`
+	}
+	if json.Unmarshal(payload, &request) == nil && request.QuoteID != "" {
+		quoteID = request.QuoteID
+	}
+	// the IDs of new bookings and quotes lists are only known from the response
+	if method != "POST" {
+		return bookingID, quoteID
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(response, &created) != nil || created.ID == "" {
+		return bookingID, quoteID
+	}
+	switch segments[len(segments)-1] {
+	case "bookings":
+		bookingID = created.ID
+	case "quotes":
+		quoteID = created.ID
+	}
+	return bookingID, quoteID
+}
@@ -0,0 +1,12 @@
+package util
+
+import "os"
+
+// lockFile is a no-op on windows, only one process should append to an audit log there
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
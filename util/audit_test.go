@@ -0,0 +1,140 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func writeTestAuditLog(t *testing.T, path string, entries ...AuditEntry) *AuditLog {
+	l, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if err := l.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return l
+}
+
+func TestAuditLogChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	now := time.Date(2021, 1, 8, 9, 0, 0, 0, time.UTC)
+
+	l := writeTestAuditLog(t, path,
+		AuditEntry{Time: now, Endpoint: "POST /v2/quotes/", Actor: "frontend", QuoteID: "list-1", StatusCode: 201},
+		AuditEntry{Time: now, Endpoint: "POST /v1/bookings/", Actor: "frontend", BookingID: "booking-1", StatusCode: 201,
+			Payload: json.RawMessage(`{ "quote_id": "list-1:quote-1" }`)})
+	l.Close()
+	// reopening continues the chain
+	l = writeTestAuditLog(t, path, AuditEntry{Time: now, Endpoint: "GET /v1/bookings/:id", Actor: "frontend", StatusCode: 200})
+	lastHash := l.LastHash()
+	l.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := VerifyAuditLog(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Entries != 3 || v.LastHash != lastHash {
+		t.Errorf("expected 3 entries ending in %s, got %+v", lastHash, v)
+	}
+
+	lines := strings.SplitAfter(string(b), "\n")
+	var chainErr *AuditChainError
+
+	tampered := strings.Replace(string(b), `"status_code":201`, `"status_code":200`, 1)
+	_, err = VerifyAuditLog(strings.NewReader(tampered))
+	if !errors.As(err, &chainErr) || chainErr.Seq != 1 {
+		t.Errorf("expected a changed entry to break the chain at seq 1, got %v", err)
+	}
+	removed := lines[0] + lines[2]
+	_, err = VerifyAuditLog(strings.NewReader(removed))
+	if !errors.As(err, &chainErr) || chainErr.Line != 2 {
+		t.Errorf("expected a removed entry to break the chain at line 2, got %v", err)
+	}
+	reordered := lines[1] + lines[0] + lines[2]
+	if _, err = VerifyAuditLog(strings.NewReader(reordered)); !errors.As(err, &chainErr) {
+		t.Errorf("expected reordered entries to break the chain, got %v", err)
+	}
+}
+
+func TestAuditLogShared(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	// two processes appending to the same log, each continues from the entries of the other
+	first := writeTestAuditLog(t, path)
+	defer first.Close()
+	second := writeTestAuditLog(t, path)
+	defer second.Close()
+	var wg sync.WaitGroup
+	for _, l := range []*AuditLog{first, second} {
+		wg.Add(1)
+		go func(l *AuditLog) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				if err := l.Append(AuditEntry{Endpoint: "GET /v1/bookings/:id", StatusCode: 200}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := VerifyAuditLog(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Entries != 40 {
+		t.Errorf("expected 40 entries, got %+v", v)
+	}
+}
+
+func TestAuditIDs(t *testing.T) {
+	tests := []struct {
+		method, url      string
+		payload, resp    string
+		booking, quoteID string
+	}{
+		{"POST", GetQuotesURL, `{}`, `{"id":"list-1"}`, "", "list-1"},
+		{"GET", GetQuotesURL + "list-1", "", `{"id":"list-1"}`, "", "list-1"},
+		{"POST", BookingURL, `{"quote_id":"list-1:quote-1"}`, `{"id":"booking-1"}`, "booking-1", "list-1:quote-1"},
+		{"GET", GetBookingDetailsURL + "booking-1", "", `{"id":"booking-1"}`, "booking-1", ""},
+		{"POST", "https://rest.sandbox.karhoo.com/v1/bookings/booking-1/cancel/", `{"reason":"OTHER_USER_REASON"}`, "", "booking-1", ""},
+		{"POST", SearchBookingsURL, `{}`, `{"bookings":[]}`, "", ""},
+		{"GET", "https://rest.sandbox.karhoo.com/v1/bookings/follow/code-1", "", "", "", ""},
+	}
+	for _, test := range tests {
+		booking, quoteID := AuditIDs(test.method, test.url, []byte(test.payload), []byte(test.resp))
+		if booking != test.booking || quoteID != test.quoteID {
+			t.Errorf("%s %s: expected booking %q quote %q, got %q %q", test.method, test.url, test.booking, test.quoteID, booking, quoteID)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RouteRequest a route to price, ID identifies the route in the results
+type RouteRequest struct {
+	ID          string      `json:"id"`
+	Origin      Geolocation `json:"origin"`
+	Destination Geolocation `json:"destination"`
+	PickupTime  string      `json:"pickup_time"`
+}
+
+// ReadRouteRequests reads the routes of a batch from a json array of routes. Routes without an ID are numbered from 1
+// in file order
+func ReadRouteRequests(r io.Reader) ([]RouteRequest, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var routes []RouteRequest
+	err := decoder.Decode(&routes)
+	if err != nil {
+		return nil, err
+	}
+	for i := range routes {
+		if routes[i].ID == "" {
+			routes[i].ID = strconv.Itoa(i + 1)
+		}
+		if err := routes[i].Origin.Validate(); err != nil {
+			return nil, fmt.Errorf("route %s: invalid origin: %w", routes[i].ID, err)
+		}
+		if err := routes[i].Destination.Validate(); err != nil {
+			return nil, fmt.Errorf("route %s: invalid destination: %w", routes[i].ID, err)
+		}
+	}
+	return routes, nil
+}
+
+// RouteQuotes quotes of a route of a batch, Err is set if the route could not be priced
+type RouteQuotes struct {
+	Route      RouteRequest
+	QuotesList *QuotesList
+	Err        error
+	Duration   time.Duration
+}
+
+// Cheapest returns the quote with the lowest price, nil if the route has no quotes
+func (r RouteQuotes) Cheapest() *Quote {
+	if r.QuotesList == nil {
+		return nil
+	}
+	var cheapest *Quote
+	for i, quote := range r.QuotesList.Quotes {
+		if cheapest == nil || quote.Price.Low < cheapest.Price.Low {
+			cheapest = &r.QuotesList.Quotes[i]
+		}
+	}
+	return cheapest
+}
+
+// batchQuoteJSON route quotes as exported to json
+type batchQuoteJSON struct {
+	Route        RouteRequest `json:"route"`
+	QuotesListID string       `json:"quotes_list_id,omitempty"`
+	Quotes       []Quote      `json:"quotes"`
+	Error        string       `json:"error,omitempty"`
+	DurationMs   int64        `json:"duration_ms"`
+}
+
+// WriteBatchQuotesJSON exports batch quote results as a json array
+func WriteBatchQuotesJSON(w io.Writer, results []RouteQuotes) error {
+	rows := make([]batchQuoteJSON, len(results))
+	for i, r := range results {
+		rows[i] = batchQuoteJSON{Route: r.Route, Quotes: []Quote{}, DurationMs: r.Duration.Milliseconds()}
+		if r.QuotesList != nil {
+			rows[i].QuotesListID = r.QuotesList.ID
+			rows[i].Quotes = r.QuotesList.Quotes
+		}
+		if r.Err != nil {
+			rows[i].Error = r.Err.Error()
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "	")
+	return encoder.Encode(rows)
+}
+
+// batchQuotesCSVHeader columns of the csv export, one row per quote, routes without quotes get a single row
+var batchQuotesCSVHeader = []string{
+	"route_id", "origin", "destination", "pickup_time", "quote_id", "fleet", "vehicle_class",
+	"currency", "price_low", "price_high", "qta_low_minutes", "qta_high_minutes", "error",
+}
+
+// csvFormulaPrefixes characters spreadsheets start a formula with
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVCells prefixes cells that a spreadsheet would run as a formula with a quote, addresses and error messages
+// come from outside
+func escapeCSVCells(row []string) []string {
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+			row[i] = "'" + cell
+		}
+	}
+	return row
+}
+
+// WriteBatchQuotesCSV exports batch quote results as csv, cells starting like a formula are escaped
+func WriteBatchQuotesCSV(w io.Writer, results []RouteQuotes) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(batchQuotesCSVHeader)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		route := []string{r.Route.ID, describeGeolocation(r.Route.Origin), describeGeolocation(r.Route.Destination), r.Route.PickupTime}
+		errorMessage := ""
+		if r.Err != nil {
+			errorMessage = r.Err.Error()
+		}
+		if r.QuotesList == nil || len(r.QuotesList.Quotes) == 0 {
+			err = writer.Write(escapeCSVCells(append(route, "", "", "", "", "", "", "", "", errorMessage)))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		for _, q := range r.QuotesList.Quotes {
+			err = writer.Write(escapeCSVCells(append(route, q.ID, q.Fleet.Name, q.Vehicle.Class, q.Price.CurrencyCode,
+				strconv.Itoa(q.Price.Low), strconv.Itoa(q.Price.High),
+				strconv.Itoa(q.Vehicle.QTA.LowMinutes), strconv.Itoa(q.Vehicle.QTA.HighMinutes), errorMessage)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
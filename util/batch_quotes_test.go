@@ -0,0 +1,76 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteBatchQuotesCSV(t *testing.T) {
+	var q Quote
+	q.ID = "quote-id"
+	q.Price.CurrencyCode = "EUR"
+	q.Price.Low = 2500
+	q.Price.High = 2700
+	q.Vehicle.Class = "Saloon"
+	results := []RouteQuotes{
+		{Route: RouteRequest{ID: "1", Origin: Geolocation{DisplayAddress: "Frankfurt Airport"}}, QuotesList: &QuotesList{Quotes: []Quote{q}}},
+		{Route: RouteRequest{ID: "2"}, Err: errors.New("not serviceable")},
+	}
+	var b bytes.Buffer
+	err := WriteBatchQuotesCSV(&b, results)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Errorf("expected header and 2 rows, got %v", lines)
+		return
+	}
+	if !strings.HasPrefix(lines[1], "1,Frankfurt Airport,") || !strings.Contains(lines[1], ",Saloon,EUR,2500,2700,") {
+		t.Errorf("unexpected quote row %s", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], ",not serviceable") {
+		t.Errorf("unexpected error row %s", lines[2])
+	}
+}
+
+func TestWriteBatchQuotesCSVEscapesFormulas(t *testing.T) {
+	results := []RouteQuotes{
+		{Route: RouteRequest{ID: "=HYPERLINK(\"http://example.com\")", Origin: Geolocation{DisplayAddress: "+49 Airport"},
+			Destination: Geolocation{DisplayAddress: "@Hbf"}}, Err: errors.New("-1 quotes")},
+	}
+	var b bytes.Buffer
+	err := WriteBatchQuotesCSV(&b, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	expected := `"'=HYPERLINK(""http://example.com"")",'+49 Airport,'@Hbf,,,,,,,,,,'-1 quotes`
+	if len(lines) != 2 || lines[1] != expected {
+		t.Errorf("expected formula cells to be escaped, got %v", lines)
+	}
+}
+
+func TestReadRouteRequests(t *testing.T) {
+	routes, err := ReadRouteRequests(strings.NewReader(`[
+		{"origin": {"latitude": 50.037933, "longitude": 8.562152, "display_address": "Frankfurt Airport"},
+		 "destination": {"latitude": "50.107145", "longitude": "8.663789"}, "pickup_time": "2021-01-08T10:30"},
+		{"id": "hbf-airport", "origin": {"latitude": 50.107145, "longitude": 8.663789},
+		 "destination": {"latitude": 50.037933, "longitude": 8.562152}}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 2 || routes[0].ID != "1" || routes[1].ID != "hbf-airport" ||
+		routes[0].Destination.Position.Latitude != 50.107145 || routes[0].PickupTime != "2021-01-08T10:30" {
+		t.Errorf("unexpected routes %+v", routes)
+	}
+
+	_, err = ReadRouteRequests(strings.NewReader(`[{"origin": {"latitude": 95, "longitude": 8}, "destination": {"latitude": 50, "longitude": 8}}]`))
+	if err == nil || !strings.Contains(err.Error(), "route 1: invalid origin") {
+		t.Errorf("expected the invalid origin of route 1 to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// e164Pattern phone numbers have to be in E.164 format, e.g. +15005550006
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// flightNumberPattern IATA flight designator, airline code followed by up to 4 digits and an optional suffix, e.g. LH400
+var flightNumberPattern = regexp.MustCompile(`^([A-Z][A-Z0-9]|[0-9][A-Z])[0-9]{1,4}[A-Z]?$`)
+
+// trainNumberPattern train number with optional operator prefix and digits separated by dashes, e.g. ICE 1326 or 1326-32
+var trainNumberPattern = regexp.MustCompile(`^([A-Z]{1,4} ?)?[0-9]{1,6}(-[0-9]{1,4})?$`)
+
+// NormalizeFlightNumber uppercases a flight number and removes spaces, "lh 400" becomes "LH400"
+func NormalizeFlightNumber(flightNumber string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(flightNumber), ""))
+}
+
+// NormalizeTrainNumber uppercases a train number and collapses spaces, "ice  1326" becomes "ICE 1326"
+func NormalizeTrainNumber(trainNumber string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(trainNumber), " "))
+}
+
+// BookingRequest request body to make a booking
+type BookingRequest struct {
+	QuoteID    string `json:"quote_id"`
+	Passengers struct {
+		AdditionalPassengers int         `json:"additional_passengers"`
+		PassengerDetails     []Passenger `json:"passenger_details"`
+		Luggage              struct {
+			Total int `json:"total"`
+		} `json:"luggage"`
+	} `json:"passengers"`
+	FlightNumber        string            `json:"flight_number,omitempty"`
+	TrainNumber         string            `json:"train_number,omitempty"`
+	Comments            string            `json:"comments,omitempty"`
+	PartnerTripID       string            `json:"partner_trip_id,omitempty"`
+	CostCenterReference string            `json:"cost_center_reference,omitempty"`
+	Meta                map[string]string `json:"meta,omitempty"`
+}
+
+// ValidationError a single invalid field of a request
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors all invalid fields of a request
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "invalid request: " + strings.Join(messages, "; ")
+}
+
+// PassengerCount number of passengers travelling, including additional passengers
+func (r *BookingRequest) PassengerCount() int {
+	return len(r.Passengers.PassengerDetails) + r.Passengers.AdditionalPassengers
+}
+
+// Validate checks the request before it is sent to karhoo, returns ValidationErrors if any field is invalid
+func (r *BookingRequest) Validate() error {
+	errs := ValidationErrors{}
+	if r.QuoteID == "" {
+		errs = append(errs, ValidationError{"quote_id", "is required"})
+	}
+	if len(r.Passengers.PassengerDetails) == 0 {
+		errs = append(errs, ValidationError{"passengers.passenger_details", "at least one passenger is required"})
+	}
+	for i, p := range r.Passengers.PassengerDetails {
+		field := fmt.Sprintf("passengers.passenger_details[%d]", i)
+		if p.FirstName == "" {
+			errs = append(errs, ValidationError{field + ".first_name", "is required"})
+		}
+		if p.LastName == "" {
+			errs = append(errs, ValidationError{field + ".last_name", "is required"})
+		}
+		if !e164Pattern.MatchString(p.PhoneNumber) {
+			errs = append(errs, ValidationError{field + ".phone_number", fmt.Sprintf("%q is not in E.164 format", p.PhoneNumber)})
+		}
+	}
+	if r.Passengers.AdditionalPassengers < 0 {
+		errs = append(errs, ValidationError{"passengers.additional_passengers", "can not be negative"})
+	}
+	if r.Passengers.Luggage.Total < 0 {
+		errs = append(errs, ValidationError{"passengers.luggage.total", "can not be negative"})
+	}
+	if r.FlightNumber != "" && !flightNumberPattern.MatchString(r.FlightNumber) {
+		errs = append(errs, ValidationError{"flight_number", fmt.Sprintf("%q is not a valid flight number", r.FlightNumber)})
+	}
+	if r.TrainNumber != "" && !trainNumberPattern.MatchString(r.TrainNumber) {
+		errs = append(errs, ValidationError{"train_number", fmt.Sprintf("%q is not a valid train number", r.TrainNumber)})
+	}
+	if r.FlightNumber != "" && r.TrainNumber != "" {
+		errs = append(errs, ValidationError{"train_number", "can not be combined with a flight number"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateForQuote validates the request against the quote, the vehicle has to carry all passengers and the fleet has to
+// support flight or train tracking if a flight or train number is given
+func (r *BookingRequest) ValidateForQuote(q Quote) error {
+	errs := ValidationErrors{}
+	if err := r.Validate(); err != nil {
+		errs = append(errs, err.(ValidationErrors)...)
+	}
+	if r.QuoteID != "" && r.QuoteID != q.ID {
+		errs = append(errs, ValidationError{"quote_id", "does not match quote " + q.ID})
+	}
+	if q.Vehicle.PassengerCapacity > 0 && r.PassengerCount() > q.Vehicle.PassengerCapacity {
+		errs = append(errs, ValidationError{"passengers", fmt.Sprintf("%d passengers exceed the passenger capacity %d of vehicle class %s",
+			r.PassengerCount(), q.Vehicle.PassengerCapacity, q.Vehicle.Class)})
+	}
+	if r.FlightNumber != "" && !q.HasCapability(CapabilityFlightTracking) {
+		errs = append(errs, ValidationError{"flight_number", "fleet " + q.Fleet.Name + " does not support flight tracking"})
+	}
+	if r.TrainNumber != "" && !q.HasCapability(CapabilityTrainTracking) {
+		errs = append(errs, ValidationError{"train_number", "fleet " + q.Fleet.Name + " does not support train tracking"})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// BookingRequestBuilder builds a BookingRequest step by step, call Build to get the validated request
+type BookingRequestBuilder struct {
+	request BookingRequest
+	quote   *Quote
+}
+
+// NewBookingRequestBuilder creates a builder for a booking of the given quote
+func NewBookingRequestBuilder(q Quote) *BookingRequestBuilder {
+	b := &BookingRequestBuilder{quote: &q}
+	b.request.QuoteID = q.ID
+	return b
+}
+
+// NewBookingRequestBuilderForQuoteID creates a builder when only the quote ID is known, capacity checks are skipped
+func NewBookingRequestBuilderForQuoteID(quoteID string) *BookingRequestBuilder {
+	b := &BookingRequestBuilder{}
+	b.request.QuoteID = quoteID
+	return b
+}
+
+// Passenger adds a passenger with contact details
+func (b *BookingRequestBuilder) Passenger(firstName, lastName, phoneNumber string) *BookingRequestBuilder {
+	return b.PassengerDetails(Passenger{FirstName: firstName, LastName: lastName, PhoneNumber: phoneNumber})
+}
+
+// PassengerDetails adds a passenger with all details
+func (b *BookingRequestBuilder) PassengerDetails(p Passenger) *BookingRequestBuilder {
+	b.request.Passengers.PassengerDetails = append(b.request.Passengers.PassengerDetails, p)
+	return b
+}
+
+// AdditionalPassengers sets the number of passengers travelling without contact details
+func (b *BookingRequestBuilder) AdditionalPassengers(n int) *BookingRequestBuilder {
+	b.request.Passengers.AdditionalPassengers = n
+	return b
+}
+
+// Luggage sets the total number of luggage
+func (b *BookingRequestBuilder) Luggage(total int) *BookingRequestBuilder {
+	b.request.Passengers.Luggage.Total = total
+	return b
+}
+
+// FlightNumber sets the flight number for airport pickups, the fleet has to support flight tracking
+func (b *BookingRequestBuilder) FlightNumber(flightNumber string) *BookingRequestBuilder {
+	b.request.FlightNumber = NormalizeFlightNumber(flightNumber)
+	return b
+}
+
+// TrainNumber sets the train number for station pickups, the fleet has to support train tracking
+func (b *BookingRequestBuilder) TrainNumber(trainNumber string) *BookingRequestBuilder {
+	b.request.TrainNumber = NormalizeTrainNumber(trainNumber)
+	return b
+}
+
+// Comments sets comments for the driver
+func (b *BookingRequestBuilder) Comments(comments string) *BookingRequestBuilder {
+	b.request.Comments = comments
+	return b
+}
+
+// PartnerTripID sets our own trip ID
+func (b *BookingRequestBuilder) PartnerTripID(partnerTripID string) *BookingRequestBuilder {
+	b.request.PartnerTripID = partnerTripID
+	return b
+}
+
+// CostCenter sets the cost center reference the trip is charged to
+func (b *BookingRequestBuilder) CostCenter(reference string) *BookingRequestBuilder {
+	b.request.CostCenterReference = reference
+	return b
+}
+
+// Meta adds a meta key/value pair
+func (b *BookingRequestBuilder) Meta(key, value string) *BookingRequestBuilder {
+	if b.request.Meta == nil {
+		b.request.Meta = map[string]string{}
+	}
+	b.request.Meta[key] = value
+	return b
+}
+
+// Build validates and returns the booking request. Every built request is a copy, changing the builder afterwards does
+// not change requests built before
+func (b *BookingRequestBuilder) Build() (*BookingRequest, error) {
+	r := b.request
+	r.Passengers.PassengerDetails = append([]Passenger(nil), b.request.Passengers.PassengerDetails...)
+	if b.request.Meta != nil {
+		r.Meta = make(map[string]string, len(b.request.Meta))
+		for k, v := range b.request.Meta {
+			r.Meta[k] = v
+		}
+	}
+	var err error
+	if b.quote != nil {
+		err = r.ValidateForQuote(*b.quote)
+	} else {
+		err = r.Validate()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestBookingRequestBuilder(t *testing.T) {
+	var q Quote
+	q.ID = "quote-id"
+	q.Vehicle.Class = "Saloon"
+	q.Vehicle.PassengerCapacity = 2
+
+	r, err := NewBookingRequestBuilder(q).
+		Passenger("Chuoxian", "Yang", "+15005550006").
+		Luggage(1).
+		Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if r.QuoteID != q.ID {
+		t.Errorf("expected quote ID %s, got %s", q.ID, r.QuoteID)
+	}
+
+	// requests built from the same builder do not share passengers or meta
+	b := NewBookingRequestBuilder(q).Passenger("Chuoxian", "Yang", "+15005550006").Meta("team", "sales")
+	first, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Meta("team", "support").Passenger("Jane", "Doe", "+15005550007")
+	second, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second.Passengers.PassengerDetails[0].FirstName = "John"
+	if first.Meta["team"] != "sales" || len(first.Passengers.PassengerDetails) != 1 ||
+		first.Passengers.PassengerDetails[0].FirstName != "Chuoxian" {
+		t.Errorf("expected the first request to be unchanged, got %+v", first)
+	}
+
+	_, err = NewBookingRequestBuilder(q).
+		Passenger("Chuoxian", "Yang", "0044 1234").
+		AdditionalPassengers(2).
+		Build()
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Errorf("expected validation errors, got %v", err)
+		return
+	}
+	if len(errs) != 2 {
+		t.Errorf("expected phone number and capacity errors, got %v", errs)
+	}
+}
+
+func TestBookingRequestValidate(t *testing.T) {
+	r := &BookingRequest{}
+	err := r.Validate()
+	if err == nil {
+		t.Error("expected missing quote ID and passengers to be invalid")
+	}
+}
+
+func TestBookingRequestFlightAndTrainNumbers(t *testing.T) {
+	var q Quote
+	q.ID = "quote-id"
+	q.Fleet.Capabilities = []string{CapabilityFlightTracking}
+
+	r, err := NewBookingRequestBuilder(q).
+		Passenger("Chuoxian", "Yang", "+15005550006").
+		FlightNumber("lh 400").
+		Build()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if r.FlightNumber != "LH400" {
+		t.Errorf("expected normalized flight number LH400, got %s", r.FlightNumber)
+	}
+
+	_, err = NewBookingRequestBuilder(q).
+		Passenger("Chuoxian", "Yang", "+15005550006").
+		TrainNumber("ICE 1326").
+		Build()
+	if err == nil {
+		t.Error("expected train number to be rejected without train tracking")
+	}
+
+	for _, flightNumber := range []string{"LH", "LH40000", "L-400"} {
+		r := &BookingRequest{FlightNumber: flightNumber}
+		if err := r.Validate(); err == nil || !containsField(err.(ValidationErrors), "flight_number") {
+			t.Errorf("expected flight number %s to be invalid", flightNumber)
+		}
+	}
+}
+
+func containsField(errs ValidationErrors, field string) bool {
+	for _, e := range errs {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
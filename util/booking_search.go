@@ -0,0 +1,109 @@
+package util
+
+import "time"
+
+// DefaultBookingSearchPageSize number of bookings fetched per page when the search does not set a limit
+const DefaultBookingSearchPageSize = 50
+
+// BookingSearch filters to search bookings, empty filters match every booking
+type BookingSearch struct {
+	Statuses            []BookingStatus `json:"statuses,omitempty"`
+	ScheduledAfter      *time.Time      `json:"date_scheduled_after,omitempty"`
+	ScheduledBefore     *time.Time      `json:"date_scheduled_before,omitempty"`
+	PartnerTravellerID  string          `json:"partner_traveller_id,omitempty"`
+	CostCenterReference string          `json:"cost_center_reference,omitempty"`
+	OrganisationID      string          `json:"organisation_id,omitempty"`
+	Pagination          Pagination      `json:"pagination"`
+}
+
+// Pagination page of results to return
+type Pagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// BookingSearchResults a page of bookings matching a search
+type BookingSearchResults struct {
+	Bookings []BookingDetails `json:"bookings"`
+}
+
+// ScheduledBetween restricts the search to bookings scheduled in [from, to)
+func (s *BookingSearch) ScheduledBetween(from, to time.Time) *BookingSearch {
+	s.ScheduledAfter = &from
+	s.ScheduledBefore = &to
+	return s
+}
+
+// ScheduledOn restricts the search to bookings scheduled on the day of t, in the location of t
+func (s *BookingSearch) ScheduledOn(t time.Time) *BookingSearch {
+	from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return s.ScheduledBetween(from, from.AddDate(0, 0, 1))
+}
+
+// BookingSearchFunc fetches one page of bookings matching a search
+type BookingSearchFunc func(search *BookingSearch) (*BookingSearchResults, error)
+
+// BookingIterator iterates over all bookings matching a search, fetching pages as needed
+//
+//	it := NewBookingIterator(search, fetch)
+//	for it.Next() {
+//		booking := it.Booking()
+//	}
+//	if it.Err() != nil { ... }
+type BookingIterator struct {
+	fetch    BookingSearchFunc
+	search   BookingSearch
+	page     []BookingDetails
+	index    int
+	lastPage bool
+	err      error
+}
+
+// NewBookingIterator creates an iterator over the bookings matching search, starting at its pagination offset
+func NewBookingIterator(search BookingSearch, fetch BookingSearchFunc) *BookingIterator {
+	if search.Pagination.Limit <= 0 {
+		search.Pagination.Limit = DefaultBookingSearchPageSize
+	}
+	return &BookingIterator{fetch: fetch, search: search, index: -1}
+}
+
+// Next advances to the next booking, it returns false when there are no more bookings or fetching a page failed
+func (it *BookingIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	if it.index < len(it.page) {
+		return true
+	}
+	if it.lastPage {
+		return false
+	}
+	results, err := it.fetch(&it.search)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	// a fetch without results has nothing left to fetch either
+	if results == nil {
+		it.page = nil
+		it.lastPage = true
+		return false
+	}
+	it.page = results.Bookings
+	it.index = 0
+	it.search.Pagination.Offset += len(results.Bookings)
+	// a short page means there is nothing left to fetch
+	it.lastPage = len(results.Bookings) < it.search.Pagination.Limit
+	return len(it.page) > 0
+}
+
+// Booking returns the current booking
+func (it *BookingIterator) Booking() *BookingDetails {
+	return &it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *BookingIterator) Err() error {
+	return it.err
+}
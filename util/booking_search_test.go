@@ -0,0 +1,113 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBookingSearchJSON(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	search := &BookingSearch{Statuses: []BookingStatus{BookingCompleted}, Pagination: Pagination{Offset: 50, Limit: 25}}
+	search.ScheduledOn(time.Date(2021, 1, 8, 18, 30, 0, 0, berlin))
+	b, err := json.Marshal(search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"statuses":["COMPLETED"],"date_scheduled_after":"2021-01-08T00:00:00+01:00",` +
+		`"date_scheduled_before":"2021-01-09T00:00:00+01:00","pagination":{"offset":50,"limit":25}}`
+	if string(b) != expected {
+		t.Errorf("unexpected search body %s", b)
+	}
+
+	// empty filters are left out, pagination is always sent
+	b, err = json.Marshal(&BookingSearch{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"pagination":{"offset":0,"limit":0}}` {
+		t.Errorf("unexpected empty search body %s", b)
+	}
+}
+
+func TestBookingSearchScheduledBetween(t *testing.T) {
+	from := time.Date(2021, 1, 8, 9, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	var search BookingSearch
+	search.ScheduledBetween(from, to)
+	if !search.ScheduledAfter.Equal(from) || !search.ScheduledBefore.Equal(to) {
+		t.Errorf("unexpected range %v - %v", search.ScheduledAfter, search.ScheduledBefore)
+	}
+
+	// a day with a daylight saving time change is 23 hours long
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip(err)
+	}
+	search.ScheduledOn(time.Date(2021, 3, 28, 12, 0, 0, 0, london))
+	if d := search.ScheduledBefore.Sub(*search.ScheduledAfter); d != 23*time.Hour {
+		t.Errorf("expected a 23 hour day, got %v", d)
+	}
+}
+
+func TestBookingIterator(t *testing.T) {
+	bookings := make([]BookingDetails, 5)
+	for i := range bookings {
+		bookings[i].ID = fmt.Sprintf("booking-%d", i)
+	}
+	var offsets []int
+	fetch := func(search *BookingSearch) (*BookingSearchResults, error) {
+		offsets = append(offsets, search.Pagination.Offset)
+		end := search.Pagination.Offset + search.Pagination.Limit
+		if end > len(bookings) {
+			end = len(bookings)
+		}
+		return &BookingSearchResults{Bookings: bookings[search.Pagination.Offset:end]}, nil
+	}
+
+	it := NewBookingIterator(BookingSearch{Pagination: Pagination{Limit: 2}}, fetch)
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Booking().ID)
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if len(ids) != 5 || ids[4] != "booking-4" {
+		t.Errorf("expected all 5 bookings, got %v", ids)
+	}
+	// the short third page ends the iteration without another fetch
+	if fmt.Sprint(offsets) != "[0 2 4]" {
+		t.Errorf("expected pages at offsets 0, 2 and 4, got %v", offsets)
+	}
+
+	// a full last page needs one more, empty, page to end
+	offsets = nil
+	it = NewBookingIterator(BookingSearch{Pagination: Pagination{Offset: 1, Limit: 2}}, fetch)
+	for it.Next() {
+	}
+	if fmt.Sprint(offsets) != "[1 3 5]" {
+		t.Errorf("expected pages at offsets 1, 3 and 5, got %v", offsets)
+	}
+
+	it = NewBookingIterator(BookingSearch{}, func(search *BookingSearch) (*BookingSearchResults, error) {
+		if search.Pagination.Limit != DefaultBookingSearchPageSize {
+			t.Errorf("expected the default page size, got %d", search.Pagination.Limit)
+		}
+		return nil, errors.New("search failed")
+	})
+	if it.Next() || it.Err() == nil {
+		t.Error("expected a failed fetch to stop the iteration with its error")
+	}
+
+	calls := 0
+	it = NewBookingIterator(BookingSearch{}, func(search *BookingSearch) (*BookingSearchResults, error) {
+		calls++
+		return nil, nil
+	})
+	if it.Next() || it.Next() || it.Err() != nil || calls != 1 {
+		t.Errorf("expected a nil page to end the iteration without error, fetched %d times, got %v", calls, it.Err())
+	}
+}
@@ -0,0 +1,190 @@
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BookingStatus status of a booking in its lifecycle
+type BookingStatus string
+
+const (
+	// BookingRequested booking was requested, no fleet has accepted it yet
+	BookingRequested BookingStatus = "REQUESTED"
+	// BookingConfirmed fleet accepted the booking
+	BookingConfirmed BookingStatus = "CONFIRMED"
+	// BookingAllocated fleet assigned a driver to the booking
+	BookingAllocated BookingStatus = "ALLOCATED"
+	// BookingDriverEnRoute driver is on the way to the pickup
+	BookingDriverEnRoute BookingStatus = "DRIVER_EN_ROUTE"
+	// BookingArrived driver arrived at the pickup
+	BookingArrived BookingStatus = "ARRIVED"
+	// BookingPOB passenger on board
+	BookingPOB BookingStatus = "POB"
+	// BookingCompleted trip finished
+	BookingCompleted BookingStatus = "COMPLETED"
+	// BookingBookerCancelled booking was cancelled by the booker
+	BookingBookerCancelled BookingStatus = "BOOKER_CANCELLED"
+	// BookingDriverCancelled booking was cancelled by the driver
+	BookingDriverCancelled BookingStatus = "DRIVER_CANCELLED"
+	// BookingKarhooCancelled booking was cancelled by karhoo
+	BookingKarhooCancelled BookingStatus = "KARHOO_CANCELLED"
+	// BookingNoDriversAvailable fleet could not find a driver
+	BookingNoDriversAvailable BookingStatus = "NO_DRIVERS_AVAILABLE"
+	// BookingFailed booking could not be processed
+	BookingFailed BookingStatus = "FAILED"
+	// BookingIncomplete trip did not finish, e.g. passenger did not show up
+	BookingIncomplete BookingStatus = "INCOMPLETE"
+	// BookingPreauthDeclined payment pre-authorisation was declined, the booking will not be served
+	BookingPreauthDeclined BookingStatus = "PREAUTH_DECLINED"
+)
+
+// bookingTransitions statuses a booking can move to from each non terminal status. Polling can miss intermediate
+// statuses, so skipping ahead in the lifecycle is allowed. Statuses missing here are terminal
+var bookingTransitions = map[BookingStatus][]BookingStatus{
+	BookingRequested: {BookingConfirmed, BookingAllocated, BookingDriverEnRoute, BookingArrived, BookingPOB,
+		BookingCompleted, BookingBookerCancelled, BookingDriverCancelled, BookingKarhooCancelled,
+		BookingNoDriversAvailable, BookingFailed, BookingPreauthDeclined},
+	BookingConfirmed: {BookingAllocated, BookingDriverEnRoute, BookingArrived, BookingPOB, BookingCompleted,
+		BookingBookerCancelled, BookingDriverCancelled, BookingKarhooCancelled, BookingNoDriversAvailable,
+		BookingFailed, BookingPreauthDeclined},
+	BookingAllocated: {BookingDriverEnRoute, BookingArrived, BookingPOB, BookingCompleted,
+		BookingBookerCancelled, BookingDriverCancelled, BookingKarhooCancelled, BookingNoDriversAvailable,
+		BookingFailed, BookingIncomplete},
+	BookingDriverEnRoute: {BookingArrived, BookingPOB, BookingCompleted,
+		BookingBookerCancelled, BookingDriverCancelled, BookingKarhooCancelled, BookingFailed, BookingIncomplete},
+	BookingArrived: {BookingPOB, BookingCompleted,
+		BookingBookerCancelled, BookingDriverCancelled, BookingKarhooCancelled, BookingFailed, BookingIncomplete},
+	BookingPOB: {BookingCompleted, BookingFailed, BookingIncomplete},
+}
+
+// Valid checks if the status is a known booking status
+func (s BookingStatus) Valid() bool {
+	switch s {
+	case BookingRequested, BookingConfirmed, BookingAllocated, BookingDriverEnRoute, BookingArrived, BookingPOB,
+		BookingCompleted, BookingBookerCancelled, BookingDriverCancelled, BookingKarhooCancelled,
+		BookingNoDriversAvailable, BookingFailed, BookingIncomplete, BookingPreauthDeclined:
+		return true
+	}
+	return false
+}
+
+// IsTerminal checks if the booking can not change status anymore, unknown statuses are not terminal
+func (s BookingStatus) IsTerminal() bool {
+	return s.Valid() && len(bookingTransitions[s]) == 0
+}
+
+// IsCancelled checks if the booking was cancelled by anyone
+func (s BookingStatus) IsCancelled() bool {
+	return s == BookingBookerCancelled || s == BookingDriverCancelled || s == BookingKarhooCancelled
+}
+
+// CanTransitionTo checks if a booking can move from status s to status to
+func (s BookingStatus) CanTransitionTo(to BookingStatus) bool {
+	for _, next := range bookingTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// BookingTransition a status change of a booking
+type BookingTransition struct {
+	BookingID string
+	From      BookingStatus
+	To        BookingStatus
+	At        time.Time
+}
+
+// InvalidTransitionError a booking was observed moving between statuses it can not move between
+type InvalidTransitionError struct {
+	BookingID string
+	From      BookingStatus
+	To        BookingStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("booking %s can not move from %s to %s", e.BookingID, e.From, e.To)
+}
+
+// BookingTransitionHook called after a booking changed status
+type BookingTransitionHook func(t BookingTransition)
+
+// BookingStateMachine keeps track of the status of bookings and validates every observed status change
+type BookingStateMachine struct {
+	mutex    sync.Mutex
+	statuses map[string]BookingStatus
+	hooks    []BookingTransitionHook
+	byStatus map[BookingStatus][]BookingTransitionHook
+}
+
+// NewBookingStateMachine creates an empty booking state machine
+func NewBookingStateMachine() *BookingStateMachine {
+	return &BookingStateMachine{
+		statuses: map[string]BookingStatus{},
+		byStatus: map[BookingStatus][]BookingTransitionHook{},
+	}
+}
+
+// OnTransition registers a hook fired on every transition
+func (m *BookingStateMachine) OnTransition(hook BookingTransitionHook) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hooks = append(m.hooks, hook)
+}
+
+// OnStatus registers a hook fired when a booking reaches the given status
+func (m *BookingStateMachine) OnStatus(status BookingStatus, hook BookingTransitionHook) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.byStatus[status] = append(m.byStatus[status], hook)
+}
+
+// Status returns the last known status of a booking
+func (m *BookingStateMachine) Status(bookingID string) (BookingStatus, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	s, ok := m.statuses[bookingID]
+	return s, ok
+}
+
+// Forget stops tracking a booking
+func (m *BookingStateMachine) Forget(bookingID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.statuses, bookingID)
+}
+
+// Observe records a status seen by polling or through a webhook. It returns whether the booking changed status, the
+// first status seen for a booking counts as a change from an empty status. Observing the current status again is a no-op.
+// Statuses karhoo added after this client are recorded and fire the hooks like any other, moves from or to them are not
+// validated. An invalid move is still recorded and fires the hooks, karhoo's status is the truth, and is then reported
+// with an *InvalidTransitionError
+func (m *BookingStateMachine) Observe(bookingID string, status BookingStatus) (bool, error) {
+	if !status.Valid() {
+		LogEvent(LevelWarn, "unknown booking status", LogField("booking_id", bookingID), LogField("status", string(status)))
+	}
+	m.mutex.Lock()
+	from, known := m.statuses[bookingID]
+	if known && from == status {
+		m.mutex.Unlock()
+		return false, nil
+	}
+	var err error
+	if known && from.Valid() && status.Valid() && !from.CanTransitionTo(status) {
+		err = &InvalidTransitionError{BookingID: bookingID, From: from, To: status}
+	}
+	m.statuses[bookingID] = status
+	hooks := append([]BookingTransitionHook{}, m.hooks...)
+	hooks = append(hooks, m.byStatus[status]...)
+	m.mutex.Unlock()
+
+	// hooks are fired without holding the lock so that they can call back into the state machine
+	t := BookingTransition{BookingID: bookingID, From: from, To: status, At: time.Now()}
+	for _, hook := range hooks {
+		hook(t)
+	}
+	return true, err
+}
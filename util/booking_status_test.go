@@ -0,0 +1,73 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestBookingStatusIsTerminal(t *testing.T) {
+	if BookingConfirmed.IsTerminal() {
+		t.Error("CONFIRMED should not be terminal")
+	}
+	for _, s := range []BookingStatus{BookingCompleted, BookingBookerCancelled, BookingNoDriversAvailable, BookingPreauthDeclined} {
+		if !s.IsTerminal() {
+			t.Errorf("%s should be terminal", s)
+		}
+	}
+	if BookingStatus("UNKNOWN").IsTerminal() {
+		t.Error("unknown status should not be terminal")
+	}
+}
+
+func TestBookingStateMachineObserve(t *testing.T) {
+	m := NewBookingStateMachine()
+	transitions := []BookingTransition{}
+	m.OnTransition(func(t BookingTransition) {
+		transitions = append(transitions, t)
+	})
+	completed := 0
+	m.OnStatus(BookingCompleted, func(t BookingTransition) {
+		completed++
+	})
+
+	for _, s := range []BookingStatus{BookingRequested, BookingConfirmed, BookingConfirmed, BookingPOB, BookingCompleted} {
+		if _, err := m.Observe("booking", s); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	if len(transitions) != 4 {
+		t.Errorf("expected 4 transitions, got %d", len(transitions))
+	}
+	if completed != 1 {
+		t.Errorf("expected completed hook to fire once, fired %d times", completed)
+	}
+
+	// an invalid move is reported after it was recorded and the hooks fired
+	changed, err := m.Observe("booking", BookingDriverEnRoute)
+	if _, ok := err.(*InvalidTransitionError); !ok {
+		t.Errorf("expected invalid transition error, got %v", err)
+	}
+	if s, _ := m.Status("booking"); !changed || s != BookingDriverEnRoute {
+		t.Errorf("expected the observed status DRIVER_EN_ROUTE to be recorded, got %s", s)
+	}
+	if len(transitions) != 5 || transitions[4].From != BookingCompleted {
+		t.Errorf("expected the invalid move to fire the hooks, got %v", transitions)
+	}
+}
+
+func TestBookingStateMachineObserveUnknownStatus(t *testing.T) {
+	m := NewBookingStateMachine()
+	transitions := []BookingTransition{}
+	m.OnTransition(func(t BookingTransition) {
+		transitions = append(transitions, t)
+	})
+
+	for _, s := range []BookingStatus{BookingConfirmed, BookingAllocated, "DRIVER_DELAYED", BookingPOB} {
+		if _, err := m.Observe("booking", s); err != nil {
+			t.Errorf("expected %s to be observed, got %v", s, err)
+		}
+	}
+	if len(transitions) != 4 || transitions[2].To != "DRIVER_DELAYED" {
+		t.Errorf("expected the unknown status to be recorded as a transition, got %v", transitions)
+	}
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+// FieldChange a single field of a booking that changed between two polls
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// BookingEvent emitted when a watched booking changed, Changes is empty for the first poll of a booking
+type BookingEvent struct {
+	BookingID string          `json:"booking_id"`
+	Details   *BookingDetails `json:"details"`
+	Changes   []FieldChange   `json:"changes"`
+	Err       error           `json:"-"`
+}
+
+// DiffBookings compares the parts of two booking details travellers care about: status, driver, vehicle and fare
+func DiffBookings(old, new *BookingDetails) []FieldChange {
+	changes := []FieldChange{}
+	add := func(field, o, n string) {
+		if o != n {
+			changes = append(changes, FieldChange{Field: field, Old: o, New: n})
+		}
+	}
+	add("status", string(old.Status), string(new.Status))
+	add("state_details", old.StateDetails, new.StateDetails)
+	add("vehicle.driver", driverName(old), driverName(new))
+	add("vehicle.driver.phone_number", old.Vehicle.Driver.PhoneNumber, new.Vehicle.Driver.PhoneNumber)
+	add("vehicle.vehicle_license_plate", old.Vehicle.VehicleLicensePlate, new.Vehicle.VehicleLicensePlate)
+	add("vehicle.description", old.Vehicle.Description, new.Vehicle.Description)
+	add("fare.total", formatAmount(old.Fare.Total, old.Fare.Currency), formatAmount(new.Fare.Total, new.Fare.Currency))
+	add("date_scheduled", old.DateScheduled.Format(time.RFC3339), new.DateScheduled.Format(time.RFC3339))
+	return changes
+}
+
+func driverName(d *BookingDetails) string {
+	if d.Vehicle.Driver.FirstName == "" && d.Vehicle.Driver.LastName == "" {
+		return ""
+	}
+	return d.Vehicle.Driver.FirstName + " " + d.Vehicle.Driver.LastName
+}
+
+func formatAmount(amount int, currency string) string {
+	if amount == 0 && currency == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", amount, currency)
+}
+
+// PollIntervals how often bookings are polled depending on how close they are to pickup
+type PollIntervals struct {
+	// Active driver is on the way or has arrived, or pickup is within NearPickup
+	Active time.Duration
+	// OnBoard passenger is on board
+	OnBoard time.Duration
+	// Idle pickup is further away than NearPickup
+	Idle time.Duration
+	// NearPickup how long before the scheduled pickup polling switches to the Active interval
+	NearPickup time.Duration
+}
+
+// DefaultPollIntervals poll intervals used when none are configured
+var DefaultPollIntervals = PollIntervals{
+	Active:     time.Second * 10,
+	OnBoard:    time.Second * 30,
+	Idle:       time.Minute * 2,
+	NearPickup: time.Minute * 15,
+}
+
+// Next returns how long to wait before polling the booking again
+func (p PollIntervals) Next(d *BookingDetails, now time.Time) time.Duration {
+	switch d.Status {
+	case BookingDriverEnRoute, BookingArrived:
+		return p.Active
+	case BookingPOB:
+		return p.OnBoard
+	}
+	// statuses unknown to this client are polled slowly until the booking moves on to a known one
+	if !d.Status.Valid() {
+		return p.Idle
+	}
+	// an ASAP booking has no scheduled date, the driver is assigned right away
+	if d.DateScheduled.IsZero() || d.DateScheduled.Sub(now) <= p.NearPickup {
+		return p.Active
+	}
+	return p.Idle
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffBookings(t *testing.T) {
+	old := &BookingDetails{Status: BookingConfirmed}
+	new := &BookingDetails{Status: BookingDriverEnRoute}
+	new.Vehicle.Driver.FirstName = "Michael"
+	new.Vehicle.Driver.LastName = "Higgins"
+	new.Vehicle.VehicleLicensePlate = "123 XYZ"
+
+	changes := DiffBookings(old, new)
+	if len(changes) != 3 {
+		t.Errorf("expected status, driver and plate changes, got %v", changes)
+		return
+	}
+	if changes[0].Field != "status" || changes[0].Old != "CONFIRMED" || changes[0].New != "DRIVER_EN_ROUTE" {
+		t.Errorf("unexpected status change %v", changes[0])
+	}
+	if len(DiffBookings(new, new)) != 0 {
+		t.Error("expected no changes between identical bookings")
+	}
+}
+
+func TestPollIntervalsNext(t *testing.T) {
+	now := time.Now()
+	d := &BookingDetails{Status: BookingConfirmed, DateScheduled: now.Add(time.Hour * 2)}
+	if next := DefaultPollIntervals.Next(d, now); next != DefaultPollIntervals.Idle {
+		t.Errorf("expected idle interval far from pickup, got %v", next)
+	}
+	d.DateScheduled = now.Add(time.Minute * 5)
+	if next := DefaultPollIntervals.Next(d, now); next != DefaultPollIntervals.Active {
+		t.Errorf("expected active interval near pickup, got %v", next)
+	}
+	d.Status = BookingPOB
+	if next := DefaultPollIntervals.Next(d, now); next != DefaultPollIntervals.OnBoard {
+		t.Errorf("expected on board interval, got %v", next)
+	}
+	d.Status = "DRIVER_DELAYED"
+	if next := DefaultPollIntervals.Next(d, now); next != DefaultPollIntervals.Idle {
+		t.Errorf("expected idle interval for an unknown status, got %v", next)
+	}
+}
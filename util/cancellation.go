@@ -0,0 +1,88 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CancelReason reason to cancel a booking
+type CancelReason string
+
+const (
+	// CancelOtherUserReason any other reason of the user
+	CancelOtherUserReason CancelReason = "OTHER_USER_REASON"
+	// CancelDriverDidntShowUp driver did not show up
+	CancelDriverDidntShowUp CancelReason = "DRIVER_DIDNT_SHOW_UP"
+	// CancelETATooLong driver would take too long to arrive
+	CancelETATooLong CancelReason = "ETA_TOO_LONG"
+	// CancelDriverIsLate driver is late
+	CancelDriverIsLate CancelReason = "DRIVER_IS_LATE"
+	// CancelCanNotFindVehicle passenger can not find the vehicle
+	CancelCanNotFindVehicle CancelReason = "CAN_NOT_FIND_VEHICLE"
+	// CancelNotNeededAnymore ride is not needed anymore
+	CancelNotNeededAnymore CancelReason = "NOT_NEEDED_ANYMORE"
+	// CancelAskedByDriverToCancel driver asked the passenger to cancel
+	CancelAskedByDriverToCancel CancelReason = "ASKED_BY_DRIVER_TO_CANCEL"
+	// CancelFoundBetterPrice passenger found a better price
+	CancelFoundBetterPrice CancelReason = "FOUND_BETTER_PRICE"
+	// CancelNotClearMeetingInstructions meeting instructions were not clear
+	CancelNotClearMeetingInstructions CancelReason = "NOT_CLEAR_MEETING_INSTRUCTIONS"
+	// CancelCouldNotContactCarrier fleet could not be contacted
+	CancelCouldNotContactCarrier CancelReason = "COULD_NOT_CONTACT_CARRIER"
+)
+
+// CancelBookingReasons all reasons accepted to cancel booking
+var CancelBookingReasons = []CancelReason{
+	CancelOtherUserReason,
+	CancelDriverDidntShowUp,
+	CancelETATooLong,
+	CancelDriverIsLate,
+	CancelCanNotFindVehicle,
+	CancelNotNeededAnymore,
+	CancelAskedByDriverToCancel,
+	CancelFoundBetterPrice,
+	CancelNotClearMeetingInstructions,
+	CancelCouldNotContactCarrier,
+}
+
+// Valid checks if karhoo accepts the cancel reason
+func (r CancelReason) Valid() bool {
+	for _, reason := range CancelBookingReasons {
+		if reason == r {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCancelReason parses a cancel reason case insensitively, e.g. "eta_too_long"
+func ParseCancelReason(s string) (CancelReason, error) {
+	r := CancelReason(strings.ToUpper(strings.TrimSpace(s)))
+	if !r.Valid() {
+		return "", fmt.Errorf("unknown cancel reason %q", s)
+	}
+	return r, nil
+}
+
+// CancellationFee fee charged when cancelling a booking now
+type CancellationFee struct {
+	CancellationFee bool `json:"cancellation_fee"`
+	Fee             struct {
+		Type     string `json:"type"`
+		Currency string `json:"currency"`
+		Value    int    `json:"value"`
+	} `json:"fee"`
+}
+
+// Applies checks if cancelling costs anything
+func (f *CancellationFee) Applies() bool {
+	return f.CancellationFee && f.Fee.Value > 0
+}
+
+// String describes the fee for the user, e.g. "cancelling will cost 5.00 GBP"
+func (f *CancellationFee) String() string {
+	if !f.Applies() {
+		return "cancelling is free of charge"
+	}
+	return "cancelling will cost " + FormatPrice(f.Fee.Value, f.Fee.Currency)
+}
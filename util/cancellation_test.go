@@ -0,0 +1,31 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestParseCancelReason(t *testing.T) {
+	r, err := ParseCancelReason(" eta_too_long ")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if r != CancelETATooLong {
+		t.Errorf("expected %s, got %s", CancelETATooLong, r)
+	}
+	if _, err = ParseCancelReason("BORED"); err == nil {
+		t.Error("expected unknown cancel reason to be rejected")
+	}
+}
+
+func TestCancellationFeeString(t *testing.T) {
+	fee := &CancellationFee{CancellationFee: true}
+	fee.Fee.Currency = "EUR"
+	fee.Fee.Value = 550
+	if s := fee.String(); s != "cancelling will cost 5.50 EUR" {
+		t.Errorf("unexpected fee description %q", s)
+	}
+	if !fee.Applies() {
+		t.Error("expected fee to apply")
+	}
+}
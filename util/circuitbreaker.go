@@ -0,0 +1,184 @@
+package util
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState state of the circuit of an endpoint
+type CircuitState string
+
+const (
+	// CircuitClosed calls go through
+	CircuitClosed CircuitState = "CLOSED"
+	// CircuitOpen calls are short-circuited without reaching karhoo
+	CircuitOpen CircuitState = "OPEN"
+	// CircuitHalfOpen a limited number of probe calls go through to check if the endpoint recovered
+	CircuitHalfOpen CircuitState = "HALF_OPEN"
+)
+
+// CircuitBreakerSettings thresholds of a circuit breaker
+type CircuitBreakerSettings struct {
+	// FailureThreshold consecutive failures opening the circuit
+	FailureThreshold int
+	// OpenTimeout how long the circuit stays open before probe calls are let through
+	OpenTimeout time.Duration
+	// HalfOpenProbes number of concurrent probe calls while half open
+	HalfOpenProbes int
+}
+
+// DefaultCircuitBreakerSettings circuit breaker settings used when none are configured
+var DefaultCircuitBreakerSettings = CircuitBreakerSettings{
+	FailureThreshold: 5,
+	OpenTimeout:      time.Second * 30,
+	HalfOpenProbes:   1,
+}
+
+// CircuitOpenError returned instead of calling an endpoint whose circuit is open
+type CircuitOpenError struct {
+	Endpoint string
+	RetryAt  time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s, retry after %s", e.Endpoint, e.RetryAt.Format(time.RFC3339))
+}
+
+// CircuitStatus state of an endpoint's circuit, for health checks
+type CircuitStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            time.Time    `json:"opened_at,omitempty"`
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+	// generation counts the times the circuit opened, calls allowed in an older generation do not count
+	generation uint64
+}
+
+// CircuitBreaker keeps a circuit per endpoint, endpoints are keys as returned by EndpointKey
+type CircuitBreaker struct {
+	settings CircuitBreakerSettings
+	mutex    sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewCircuitBreaker creates a circuit breaker with all circuits closed
+func NewCircuitBreaker(settings CircuitBreakerSettings) *CircuitBreaker {
+	if settings.FailureThreshold < 1 {
+		settings.FailureThreshold = 1
+	}
+	if settings.HalfOpenProbes < 1 {
+		settings.HalfOpenProbes = 1
+	}
+	return &CircuitBreaker{settings: settings, circuits: map[string]*circuit{}}
+}
+
+func (b *CircuitBreaker) circuit(endpoint string) *circuit {
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[endpoint] = c
+	}
+	return c
+}
+
+// Allow checks if a call to the endpoint may go through, returns a *CircuitOpenError if not.
+// Every allowed call has to be followed by Record with the returned generation
+func (b *CircuitBreaker) Allow(endpoint string, now time.Time) (uint64, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	c := b.circuit(endpoint)
+	if c.state == CircuitOpen {
+		retryAt := c.openedAt.Add(b.settings.OpenTimeout)
+		if now.Before(retryAt) {
+			return 0, &CircuitOpenError{Endpoint: endpoint, RetryAt: retryAt}
+		}
+		c.state = CircuitHalfOpen
+		c.probes = 0
+	}
+	if c.state == CircuitHalfOpen {
+		if c.probes >= b.settings.HalfOpenProbes {
+			return 0, &CircuitOpenError{Endpoint: endpoint, RetryAt: now.Add(time.Second)}
+		}
+		c.probes++
+	}
+	return c.generation, nil
+}
+
+// Record records the outcome of a call allowed in generation. Outcomes of calls allowed before the circuit last opened
+// are ignored, while open or half open only a probe can close it again
+func (b *CircuitBreaker) Record(endpoint string, generation uint64, success bool, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	c := b.circuit(endpoint)
+	if c.state == CircuitOpen || generation != c.generation {
+		return
+	}
+	if c.state == CircuitHalfOpen {
+		c.probes--
+	}
+	if success {
+		c.state = CircuitClosed
+		c.failures = 0
+		return
+	}
+	c.failures++
+	// a failed probe opens the circuit again right away
+	if c.state == CircuitHalfOpen || c.failures >= b.settings.FailureThreshold {
+		c.state = CircuitOpen
+		c.openedAt = now
+		c.generation++
+	}
+}
+
+// States returns the circuit of every endpoint called so far
+func (b *CircuitBreaker) States() map[string]CircuitStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	states := map[string]CircuitStatus{}
+	for endpoint, c := range b.circuits {
+		states[endpoint] = CircuitStatus{State: c.state, ConsecutiveFailures: c.failures, OpenedAt: c.openedAt}
+	}
+	return states
+}
+
+// Healthy checks that no circuit is open
+func (b *CircuitBreaker) Healthy() bool {
+	for _, status := range b.States() {
+		if status.State == CircuitOpen {
+			return false
+		}
+	}
+	return true
+}
+
+// CircuitBreakerTransport http transport short-circuiting calls to endpoints whose circuit is open. Transport errors
+// and 5xx responses count as failures
+type CircuitBreakerTransport struct {
+	Breaker *CircuitBreaker
+	// Base transport making the calls, http.DefaultTransport if nil
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	endpoint := EndpointKey(req.Method, req.URL.String())
+	generation, err := t.Breaker.Allow(endpoint, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	res, err := base.RoundTrip(req)
+	t.Breaker.Record(endpoint, generation, err == nil && res.StatusCode < http.StatusInternalServerError, time.Now())
+	return res, err
+}
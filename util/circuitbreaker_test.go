@@ -0,0 +1,82 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute, HalfOpenProbes: 1})
+	now := time.Now()
+	endpoint := "GET /v1/bookings/:id"
+	for i := 0; i < 2; i++ {
+		generation, err := b.Allow(endpoint, now)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		b.Record(endpoint, generation, false, now)
+	}
+	if _, err := b.Allow(endpoint, now); err == nil {
+		t.Error("expected circuit to be open after 2 failures")
+	} else if _, ok := err.(*CircuitOpenError); !ok {
+		t.Errorf("expected a *CircuitOpenError, got %v", err)
+	}
+	if b.Healthy() {
+		t.Error("expected breaker with an open circuit to be unhealthy")
+	}
+
+	later := now.Add(time.Minute)
+	probe, err := b.Allow(endpoint, later)
+	if err != nil {
+		t.Errorf("expected probe call to be allowed, got %v", err)
+	}
+	if _, err := b.Allow(endpoint, later); err == nil {
+		t.Error("expected a single probe call while half open")
+	}
+	b.Record(endpoint, probe, true, later)
+	if state := b.States()[endpoint].State; state != CircuitClosed {
+		t.Errorf("expected circuit to close after a successful probe, got %s", state)
+	}
+}
+
+func TestCircuitBreakerLateSuccess(t *testing.T) {
+	b := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenProbes: 1})
+	now := time.Now()
+	endpoint := "GET /v1/bookings/:id"
+	// two calls in flight, the first one fails and opens the circuit before the second one returns
+	var generations []uint64
+	for i := 0; i < 2; i++ {
+		generation, err := b.Allow(endpoint, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generations = append(generations, generation)
+	}
+	b.Record(endpoint, generations[0], false, now)
+	b.Record(endpoint, generations[1], true, now)
+	if state := b.States()[endpoint].State; state != CircuitOpen {
+		t.Errorf("expected a late success to leave the circuit open, got %s", state)
+	}
+	if _, err := b.Allow(endpoint, now); err == nil {
+		t.Error("expected calls to be rejected until the open timeout passed")
+	}
+
+	// the same late success arriving while half open does not close the circuit either, only the probe does
+	later := now.Add(time.Minute)
+	probe, err := b.Allow(endpoint, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Record(endpoint, generations[1], true, later)
+	if state := b.States()[endpoint].State; state != CircuitHalfOpen {
+		t.Errorf("expected a call started before the trip to leave the circuit half open, got %s", state)
+	}
+	if _, err := b.Allow(endpoint, later); err == nil {
+		t.Error("expected the probe to still be in flight")
+	}
+	b.Record(endpoint, probe, false, later)
+	if state := b.States()[endpoint].State; state != CircuitOpen {
+		t.Errorf("expected the failed probe to open the circuit again, got %s", state)
+	}
+}
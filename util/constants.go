@@ -4,39 +4,50 @@ import "time"
 
 // Credentials username/password retrieved from yaml config file
 type Credentials struct {
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
+	Username string `yaml:"username" redact:"secret"`
+	Password string `yaml:"password" redact:"secret"`
 }
 
 // ErrorInfo generic failed http response
 type ErrorInfo struct {
-	Code    string `json:"code"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Details []ErrorDetail `json:"details"`
+}
+
+// ErrorDetail one problem of a failed http response, e.g. an invalid field
+type ErrorDetail struct {
 	Message string `json:"message"`
-	Details []struct {
-		Message string `json:"message"`
-		Detail  string `json:"detail"`
-	} `json:"details"`
+	Detail  string `json:"detail"`
 }
 
 // AuthInfo response to get access token
 type AuthInfo struct {
-	AccessToken    string `json:"access_token"`
+	AccessToken    string `json:"access_token" redact:"secret"`
 	ExpiresIn      int    `json:"expires_in"`
-	RefreshToken   string `json:"refresh_token"`
+	RefreshToken   string `json:"refresh_token" redact:"secret"`
 	ExpirationTime time.Time
+	// Actor who the calls made with this auth info are made for, recorded in the audit log and never stored
+	Actor string `json:"-"`
+	// CorrelationID ties the log events of the calls made with this auth info to the command or request they serve
+	CorrelationID string `json:"-"`
 }
 
 // RefreshInfo response to refresh access token
 type RefreshInfo struct {
-	AccessToken string `json:"access_token"`
+	AccessToken string `json:"access_token" redact:"secret"`
 	ExpiresIn   int    `json:"expires_in"`
 }
 
-// Geolocation geolocation with latitude/longitude coordinates
+// Geolocation geolocation with latitude/longitude coordinates, serialized with latitude and longitude as strings
 type Geolocation struct {
-	Latitude       string `json:"latitude"`
-	Longitude      string `json:"longitude"`
-	DisplayAddress string `json:"display_address"`
+	Position       Position `json:"-"`
+	DisplayAddress string   `json:"display_address"`
+	PlaceID        string   `json:"place_id,omitempty"`
+	PoiType        string   `json:"poi_type,omitempty"`
+	Timezone       string   `json:"timezone,omitempty"`
+	// Precision decimal places of latitude and longitude when serialized, 0 means DefaultCoordinatePrecision
+	Precision int `json:"-"`
 }
 
 // WebhookSubscription the registered webhook url
@@ -54,102 +65,130 @@ type QuotesList struct {
 			Types   []string `json:"types"`
 		} `json:"vehicles"`
 	} `json:"availability"`
-	Quotes []struct {
-		ID    string `json:"id"`
-		Price struct {
-			CurrencyCode string `json:"currency_code"`
-			High         int    `json:"high"`
-			Low          int    `json:"low"`
-			Net          struct {
-				High int `json:"high"`
-				Low  int `json:"low"`
-			} `json:"net"`
-		} `json:"price"`
-		PickUpType string `json:"pick_up_type"`
-		QuoteType  string `json:"quote_type"`
-		Source     string `json:"source"`
-		Fleet      struct {
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-			Rating      struct {
-				Count int `json:"count"`
-				Score int `json:"score"`
-			} `json:"rating"`
-			LogoURL            string   `json:"logo_url"`
-			TermsConditionsURL string   `json:"terms_conditions_url"`
-			PhoneNumber        string   `json:"phone_number"`
-			Capabilities       []string `json:"capabilities"`
-		} `json:"fleet"`
-		Vehicle struct {
-			QTA struct {
-				HighMinutes int `json:"high_minutes"`
-				LowMinutes  int `json:"low_minutes"`
-			} `json:"qta"`
-			Class             string   `json:"class"`
-			Type              string   `json:"type"`
-			PassengerCapacity int      `json:"passenger_capacity"`
-			LuggageCapacity   int      `json:"luggage_capacity"`
-			Tags              []string `json:"tags"`
-		} `json:"vehicle"`
-	} `json:"quotes"`
-	Status   string `json:"status"`
-	Validity int    `json:"validity"`
+	Quotes   []Quote `json:"quotes"`
+	Status   string  `json:"status"`
+	Validity int     `json:"validity"`
+}
+
+const (
+	// QuotesListProgressing fleets are still adding quotes to the quotes list
+	QuotesListProgressing = "PROGRESSING"
+	// QuotesListCompleted all fleets have quoted
+	QuotesListCompleted = "COMPLETED"
+)
+
+// Quote a single quote of a quotes list
+type Quote struct {
+	ID    string `json:"id"`
+	Price struct {
+		CurrencyCode string `json:"currency_code"`
+		High         int    `json:"high"`
+		Low          int    `json:"low"`
+		Net          struct {
+			High int `json:"high"`
+			Low  int `json:"low"`
+		} `json:"net"`
+	} `json:"price"`
+	PickUpType string `json:"pick_up_type"`
+	QuoteType  string `json:"quote_type"`
+	Source     string `json:"source"`
+	Fleet      struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Rating      struct {
+			Count int `json:"count"`
+			Score int `json:"score"`
+		} `json:"rating"`
+		LogoURL            string   `json:"logo_url"`
+		TermsConditionsURL string   `json:"terms_conditions_url"`
+		PhoneNumber        string   `json:"phone_number"`
+		Capabilities       []string `json:"capabilities"`
+	} `json:"fleet"`
+	Vehicle struct {
+		QTA struct {
+			HighMinutes int `json:"high_minutes"`
+			LowMinutes  int `json:"low_minutes"`
+		} `json:"qta"`
+		Class             string   `json:"class"`
+		Type              string   `json:"type"`
+		PassengerCapacity int      `json:"passenger_capacity"`
+		LuggageCapacity   int      `json:"luggage_capacity"`
+		Tags              []string `json:"tags"`
+	} `json:"vehicle"`
+}
+
+// Passenger details of a passenger of a booking
+type Passenger struct {
+	FirstName   string `json:"first_name" redact:"personal"`
+	LastName    string `json:"last_name" redact:"personal"`
+	Email       string `json:"email,omitempty" redact:"personal"`
+	PhoneNumber string `json:"phone_number" redact:"personal"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+// HasCapability checks if the fleet of the quote advertises a capability, e.g. CapabilityFlightTracking
+func (q Quote) HasCapability(capability string) bool {
+	for _, c := range q.Fleet.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// BreakdownItem a line item of a quote or fare
+type BreakdownItem struct {
+	Value       int    `json:"value"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Fare amount charged for a trip
+type Fare struct {
+	Total           int             `json:"total"`
+	Currency        string          `json:"currency"`
+	GratuityPercent int             `json:"gratuity_percent"`
+	Breakdown       []BreakdownItem `json:"breakdown"`
 }
 
 // BookingDetails details of a booking
 type BookingDetails struct {
 	ID         string `json:"id"`
 	Passengers struct {
-		AdditionalPassengers int `json:"additional_passengers"`
-		PassengerDetails     []struct {
-			FirstName   string `json:"first_name"`
-			LastName    string `json:"last_name"`
-			Email       string `json:"email"`
-			PhoneNumber string `json:"phone_number"`
-			Locale      string `json:"locale"`
-		} `json:"passenger_details"`
-		Luggage struct {
+		AdditionalPassengers int         `json:"additional_passengers"`
+		PassengerDetails     []Passenger `json:"passenger_details"`
+		Luggage              struct {
 			Total int `json:"total"`
 		} `json:"luggage"`
 	} `json:"passengers"`
-	PartnerTravellerID string `json:"partner_traveller_id"`
-	Status             string `json:"status"`
-	StateDetails       string `json:"state_details"`
+	PartnerTravellerID string        `json:"partner_traveller_id"`
+	Status             BookingStatus `json:"status"`
+	StateDetails       string        `json:"state_details"`
 	Origin             struct {
-		DisplayAddress string `json:"display_address"`
-		Position       struct {
-			Latitude  float64 `json:"latitude"`
-			Longitude float64 `json:"longitude"`
-		} `json:"position"`
-		PlaceID  string `json:"place_id"`
-		PoiType  string `json:"poi_type"`
-		Timezone string `json:"timezone"`
+		DisplayAddress string   `json:"display_address"`
+		Position       Position `json:"position"`
+		PlaceID        string   `json:"place_id"`
+		PoiType        string   `json:"poi_type"`
+		Timezone       string   `json:"timezone"`
 	} `json:"origin"`
 	Destination struct {
-		DisplayAddress string `json:"display_address"`
-		Position       struct {
-			Latitude  float64 `json:"latitude"`
-			Longitude float64 `json:"longitude"`
-		} `json:"position"`
-		PlaceID  string `json:"place_id"`
-		PoiType  string `json:"poi_type"`
-		Timezone string `json:"timezone"`
+		DisplayAddress string   `json:"display_address"`
+		Position       Position `json:"position"`
+		PlaceID        string   `json:"place_id"`
+		PoiType        string   `json:"poi_type"`
+		Timezone       string   `json:"timezone"`
 	} `json:"destination"`
 	DateScheduled time.Time `json:"date_scheduled"`
 	Quote         struct {
-		Type            string `json:"type"`
-		Total           int    `json:"total"`
-		Currency        string `json:"currency"`
-		GratuityPercent int    `json:"gratuity_percent"`
-		Breakdown       []struct {
-			Value       int    `json:"value"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"breakdown"`
-		VehicleClass      string `json:"vehicle_class"`
-		QtaHighMinutes    int    `json:"qta_high_minutes"`
-		QtaLowMinutes     int    `json:"qta_low_minutes"`
+		Type              string          `json:"type"`
+		Total             int             `json:"total"`
+		Currency          string          `json:"currency"`
+		GratuityPercent   int             `json:"gratuity_percent"`
+		Breakdown         []BreakdownItem `json:"breakdown"`
+		VehicleClass      string          `json:"vehicle_class"`
+		QtaHighMinutes    int             `json:"qta_high_minutes"`
+		QtaLowMinutes     int             `json:"qta_low_minutes"`
 		VehicleAttributes struct {
 			PassengerCapacity int  `json:"passenger_capacity"`
 			LuggageCapacity   int  `json:"luggage_capacity"`
@@ -161,16 +200,7 @@ type BookingDetails struct {
 		HighPrice int    `json:"high_price"`
 		LowPrice  int    `json:"low_price"`
 	} `json:"quote"`
-	Fare struct {
-		Total           int    `json:"total"`
-		Currency        string `json:"currency"`
-		GratuityPercent int    `json:"gratuity_percent"`
-		Breakdown       []struct {
-			Value       int    `json:"value"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-		} `json:"breakdown"`
-	} `json:"fare"`
+	Fare           Fare   `json:"fare"`
 	ExternalTripID string `json:"external_trip_id"`
 	DisplayTripID  string `json:"display_trip_id"`
 	FleetInfo      struct {
@@ -187,11 +217,11 @@ type BookingDetails struct {
 		Description         string `json:"description"`
 		VehicleLicensePlate string `json:"vehicle_license_plate"`
 		Driver              struct {
-			FirstName     string `json:"first_name"`
-			LastName      string `json:"last_name"`
-			PhoneNumber   string `json:"phone_number"`
+			FirstName     string `json:"first_name" redact:"personal"`
+			LastName      string `json:"last_name" redact:"personal"`
+			PhoneNumber   string `json:"phone_number" redact:"personal"`
 			PhotoURL      string `json:"photo_url"`
-			LicenseNumber string `json:"license_number"`
+			LicenseNumber string `json:"license_number" redact:"personal"`
 		} `json:"driver"`
 		Attributes struct {
 			PassengerCapacity int  `json:"passenger_capacity"`
@@ -207,26 +237,23 @@ type BookingDetails struct {
 	TrainNumber   string `json:"train_number"`
 	DateBooked    string `json:"date_booked"`
 	MeetingPoint  struct {
-		Position struct {
-			Latitude  float64 `json:"latitude"`
-			Longitude float64 `json:"longitude"`
-		} `json:"position"`
-		Type         string `json:"type"`
-		Instructions string `json:"instructions"`
-		Note         string `json:"note"`
+		Position     Position `json:"position"`
+		Type         string   `json:"type"`
+		Instructions string   `json:"instructions"`
+		Note         string   `json:"note"`
 	} `json:"meeting_point"`
 	Agent struct {
 		UserID           string `json:"user_id"`
-		UserName         string `json:"user_name"`
+		UserName         string `json:"user_name" redact:"personal"`
 		OrganisationID   string `json:"organisation_id"`
 		OrganisationName string `json:"organisation_name"`
 	} `json:"agent"`
 	CostCenterReference string `json:"cost_center_reference"`
 	CancelledBy         struct {
-		FirstName string `json:"first_name"`
-		LastName  string `json:"last_name"`
+		FirstName string `json:"first_name" redact:"personal"`
+		LastName  string `json:"last_name" redact:"personal"`
 		ID        string `json:"id"`
-		Email     string `json:"email"`
+		Email     string `json:"email" redact:"personal"`
 	} `json:"cancelled_by"`
 	FollowCode string `json:"follow_code"`
 	Meta       struct {
@@ -245,30 +272,49 @@ const (
 	RefreshAccessTokenURL = "https://rest.sandbox.karhoo.com/v1/auth/refresh"
 	// GetQuotesURL url to get quotes
 	GetQuotesURL = "https://rest.sandbox.karhoo.com/v2/quotes/"
+	// QuotesCoverageURL url to check if any fleet serves a position
+	QuotesCoverageURL = "https://rest.sandbox.karhoo.com/v2/quotes/coverage?latitude=%s&longitude=%s&local_time_of_pickup=%s"
 	// RetrieveQuoteList url to retrieve quote list
 	RetrieveQuoteList = "https://rest.sandbox.karhoo.com/v2/quotes/"
 	// BookingURL url to make a booking
 	BookingURL = "https://rest.sandbox.karhoo.com/v1/bookings/"
 	// GetBookingDetailsURL url to get booking details
 	GetBookingDetailsURL = "https://rest.sandbox.karhoo.com/v1/bookings/"
+	// SearchBookingsURL url to search bookings
+	SearchBookingsURL = "https://rest.sandbox.karhoo.com/v1/bookings/search"
+	// TrackDriverURL url to track the driver of a booking
+	TrackDriverURL = "https://rest.sandbox.karhoo.com/v1/bookings/%s/track"
+	// FollowBookingURL url to get booking details with a follow code shared with passengers
+	FollowBookingURL = "https://rest.sandbox.karhoo.com/v1/bookings/follow/%s"
+	// TrackDriverByFollowCodeURL url to track the driver of a booking with a follow code shared with passengers
+	TrackDriverByFollowCodeURL = "https://rest.sandbox.karhoo.com/v1/bookings/follow/%s/track"
+	// FareURL url to get the final fare of a booking
+	FareURL = "https://rest.sandbox.karhoo.com/v1/bookings/%s/fare"
+	// AddressAutocompleteURL url to get address suggestions for a partial address
+	AddressAutocompleteURL = "https://rest.sandbox.karhoo.com/v1/locations/address-autocomplete"
+	// PlaceDetailsURL url to get position and address of a place
+	PlaceDetailsURL = "https://rest.sandbox.karhoo.com/v1/locations/place-details"
+	// ReverseGeocodeURL url to get the place at a position
+	ReverseGeocodeURL = "https://rest.sandbox.karhoo.com/v1/locations/reverse-geocode?latitude=%f&longitude=%f"
 	// CancelBookingURL url to cancel booking
 	CancelBookingURL = "https://rest.sandbox.karhoo.com/v1/bookings/%s/cancel/"
+	// CancellationFeeURL url to get the fee charged when cancelling a booking
+	CancellationFeeURL = "https://rest.sandbox.karhoo.com/v1/bookings/%s/cancel-fee"
 	// RegisterWebhookURL url to register webhook
 	RegisterWebhookURL = "https://rest.sandbox.karhoo.com/v1/webhooks/"
 	// ReturnSubscriptionURL url to return current webhook subscription for user
 	ReturnSubscriptionURL = "https://rest.sandbox.karhoo.com/v1/webhooks/"
 )
 
-// CancelBookingReasons reason strings to cancel booking
-var CancelBookingReasons = []string{
-	"OTHER_USER_REASON",
-	"DRIVER_DIDNT_SHOW_UP",
-	"ETA_TOO_LONG",
-	"DRIVER_IS_LATE",
-	"CAN_NOT_FIND_VEHICLE",
-	"NOT_NEEDED_ANYMORE",
-	"ASKED_BY_DRIVER_TO_CANCEL",
-	"FOUND_BETTER_PRICE",
-	"NOT_CLEAR_MEETING_INSTRUCTIONS",
-	"COULD_NOT_CONTACT_CARRIER",
-}
+const (
+	// CapabilityFlightTracking fleet tracks flights so that pickups follow the flight arrival
+	CapabilityFlightTracking = "flight_tracking"
+	// CapabilityTrainTracking fleet tracks trains so that pickups follow the train arrival
+	CapabilityTrainTracking = "train_tracking"
+	// CapabilityGPSTracking fleet shares driver positions
+	CapabilityGPSTracking = "gps_tracking"
+	// CapabilityVehicleDetails fleet shares vehicle details
+	CapabilityVehicleDetails = "vehicle_details"
+	// CapabilityDriverDetails fleet shares driver details
+	CapabilityDriverDetails = "driver_details"
+)
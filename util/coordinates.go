@@ -0,0 +1,181 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCoordinatePrecision decimal places of serialized coordinates, 6 decimal places are about 10 cm
+const DefaultCoordinatePrecision = 6
+
+// earthRadius mean earth radius in meters
+const earthRadius = 6371008.8
+
+// Position latitude/longitude coordinates of a place, a driver etc.
+type Position struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewPosition creates a validated position
+func NewPosition(latitude, longitude float64) (Position, error) {
+	p := Position{Latitude: latitude, Longitude: longitude}
+	return p, p.Validate()
+}
+
+// Validate checks that latitude and longitude are within range
+func (p Position) Validate() error {
+	if math.IsNaN(p.Latitude) || math.IsNaN(p.Longitude) {
+		return errors.New("coordinates are not a number")
+	}
+	if p.Latitude < -90 || p.Latitude > 90 {
+		// a latitude out of range with a longitude that would be a valid latitude usually means they were swapped
+		if p.Longitude >= -90 && p.Longitude <= 90 && p.Latitude >= -180 && p.Latitude <= 180 {
+			return fmt.Errorf("latitude %f out of range [-90, 90], latitude and longitude might be swapped", p.Latitude)
+		}
+		return fmt.Errorf("latitude %f out of range [-90, 90]", p.Latitude)
+	}
+	if p.Longitude < -180 || p.Longitude > 180 {
+		return fmt.Errorf("longitude %f out of range [-180, 180]", p.Longitude)
+	}
+	return nil
+}
+
+// DistanceTo great circle distance in meters to another position, using the haversine formula
+func (p Position) DistanceTo(other Position) float64 {
+	lat1 := p.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - p.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// String formats the position as "latitude,longitude"
+func (p Position) String() string {
+	return formatCoordinate(p.Latitude, DefaultCoordinatePrecision) + "," + formatCoordinate(p.Longitude, DefaultCoordinatePrecision)
+}
+
+// BoundingBox area between a south west and a north east corner, the box can cross the antimeridian
+type BoundingBox struct {
+	SouthWest Position `json:"south_west"`
+	NorthEast Position `json:"north_east"`
+}
+
+// Contains checks if a position is inside the bounding box, edges included
+func (b BoundingBox) Contains(p Position) bool {
+	if p.Latitude < b.SouthWest.Latitude || p.Latitude > b.NorthEast.Latitude {
+		return false
+	}
+	if b.SouthWest.Longitude <= b.NorthEast.Longitude {
+		return p.Longitude >= b.SouthWest.Longitude && p.Longitude <= b.NorthEast.Longitude
+	}
+	// box crosses the antimeridian
+	return p.Longitude >= b.SouthWest.Longitude || p.Longitude <= b.NorthEast.Longitude
+}
+
+// BoundingBoxAround returns the box containing all positions within radius meters of center. Boxes reaching a pole
+// or spanning 360 degrees of longitude cover all longitudes
+func BoundingBoxAround(center Position, radius float64) BoundingBox {
+	dLat := radius / earthRadius * 180 / math.Pi
+	b := BoundingBox{
+		SouthWest: Position{Latitude: math.Max(-90, center.Latitude-dLat), Longitude: -180},
+		NorthEast: Position{Latitude: math.Min(90, center.Latitude+dLat), Longitude: 180},
+	}
+	if b.SouthWest.Latitude <= -90 || b.NorthEast.Latitude >= 90 {
+		return b
+	}
+	dLon := dLat / math.Cos(center.Latitude*math.Pi/180)
+	if dLon >= 180 {
+		return b
+	}
+	b.SouthWest.Longitude = normalizeLongitude(center.Longitude - dLon)
+	b.NorthEast.Longitude = normalizeLongitude(center.Longitude + dLon)
+	return b
+}
+
+// normalizeLongitude wraps a longitude into [-180, 180]
+func normalizeLongitude(longitude float64) float64 {
+	longitude = math.Mod(longitude+180, 360)
+	if longitude < 0 {
+		longitude += 360
+	}
+	return longitude - 180
+}
+
+func formatCoordinate(c float64, precision int) string {
+	return strconv.FormatFloat(c, 'f', precision, 64)
+}
+
+// Validate checks the coordinates of the geolocation
+func (g Geolocation) Validate() error {
+	return g.Position.Validate()
+}
+
+// geolocationJSON geolocation as sent to and received from karhoo
+type geolocationJSON struct {
+	Latitude       json.RawMessage `json:"latitude"`
+	Longitude      json.RawMessage `json:"longitude"`
+	DisplayAddress string          `json:"display_address"`
+	PlaceID        string          `json:"place_id,omitempty"`
+	PoiType        string          `json:"poi_type,omitempty"`
+	Timezone       string          `json:"timezone,omitempty"`
+}
+
+// MarshalJSON serializes latitude and longitude as strings with the precision of the geolocation
+func (g Geolocation) MarshalJSON() ([]byte, error) {
+	precision := g.Precision
+	if precision <= 0 {
+		precision = DefaultCoordinatePrecision
+	}
+	latitude, _ := json.Marshal(formatCoordinate(g.Position.Latitude, precision))
+	longitude, _ := json.Marshal(formatCoordinate(g.Position.Longitude, precision))
+	return json.Marshal(geolocationJSON{
+		Latitude:       latitude,
+		Longitude:      longitude,
+		DisplayAddress: g.DisplayAddress,
+		PlaceID:        g.PlaceID,
+		PoiType:        g.PoiType,
+		Timezone:       g.Timezone,
+	})
+}
+
+// UnmarshalJSON accepts latitude and longitude both as strings and as numbers, both are required. A null geolocation
+// is left unchanged
+func (g *Geolocation) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var j geolocationJSON
+	err := json.Unmarshal(data, &j)
+	if err != nil {
+		return err
+	}
+	latitude, err := parseCoordinate(j.Latitude)
+	if err != nil {
+		return fmt.Errorf("invalid latitude: %w", err)
+	}
+	longitude, err := parseCoordinate(j.Longitude)
+	if err != nil {
+		return fmt.Errorf("invalid longitude: %w", err)
+	}
+	*g = Geolocation{
+		Position:       Position{Latitude: latitude, Longitude: longitude},
+		DisplayAddress: j.DisplayAddress,
+		PlaceID:        j.PlaceID,
+		PoiType:        j.PoiType,
+		Timezone:       j.Timezone,
+	}
+	return nil
+}
+
+func parseCoordinate(raw json.RawMessage) (float64, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, errors.New("missing")
+	}
+	return strconv.ParseFloat(strings.Trim(string(raw), `"`), 64)
+}
@@ -0,0 +1,85 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestPositionValidate(t *testing.T) {
+	if _, err := NewPosition(50.037933, 8.562152); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewPosition(50.037933, 188.562152); err == nil {
+		t.Error("expected longitude out of range")
+	}
+	if _, err := NewPosition(120, 50); err == nil {
+		t.Error("expected latitude out of range")
+	}
+}
+
+func TestPositionDistanceTo(t *testing.T) {
+	frankfurtAirport := Position{Latitude: 50.037933, Longitude: 8.562152}
+	frankfurtCentralStation := Position{Latitude: 50.107145, Longitude: 8.663789}
+	distance := frankfurtAirport.DistanceTo(frankfurtCentralStation)
+	if math.Abs(distance-10578) > 50 {
+		t.Errorf("expected about 10.6 km, got %f m", distance)
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	center := Position{Latitude: 50.037933, Longitude: 8.562152}
+	b := BoundingBoxAround(center, 5000)
+	if !b.Contains(center) {
+		t.Error("expected box to contain its center")
+	}
+	if b.Contains(Position{Latitude: 50.107145, Longitude: 8.663789}) {
+		t.Error("expected position 10 km away to be outside the box")
+	}
+	fiji := BoundingBox{SouthWest: Position{Latitude: -21, Longitude: 176}, NorthEast: Position{Latitude: -12, Longitude: -178}}
+	if !fiji.Contains(Position{Latitude: -17, Longitude: 179.5}) || !fiji.Contains(Position{Latitude: -17, Longitude: -179}) {
+		t.Error("expected box crossing the antimeridian to contain positions on both sides")
+	}
+
+	if b := BoundingBoxAround(Position{Latitude: 85.5, Longitude: 179}, 50000); !b.Contains(Position{Latitude: 85.5, Longitude: -179.5}) {
+		t.Errorf("expected box around the antimeridian to wrap, got %+v", b)
+	}
+}
+
+func TestBoundingBoxAroundPole(t *testing.T) {
+	for _, center := range []Position{{Latitude: 90}, {Latitude: -90, Longitude: 45}, {Latitude: 89.999, Longitude: 10}} {
+		b := BoundingBoxAround(center, 1000)
+		if b.SouthWest.Longitude != -180 || b.NorthEast.Longitude != 180 {
+			t.Errorf("expected box at %v to cover all longitudes, got %+v", center, b)
+		}
+		if !b.Contains(center) || !b.Contains(Position{Latitude: center.Latitude, Longitude: -100}) {
+			t.Errorf("expected box at %v to contain positions at every longitude, got %+v", center, b)
+		}
+	}
+}
+
+func TestGeolocationJSON(t *testing.T) {
+	g := Geolocation{Position: Position{Latitude: 50.0379331234, Longitude: 8.562152}, DisplayAddress: "Frankfurt Airport", Precision: 4}
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(b) != `{"latitude":"50.0379","longitude":"8.5622","display_address":"Frankfurt Airport"}` {
+		t.Errorf("unexpected json %s", b)
+	}
+	var decoded Geolocation
+	err = json.Unmarshal([]byte(`{"latitude":50.0379,"longitude":"8.5622","display_address":"Frankfurt Airport"}`), &decoded)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if decoded.Position.Latitude != 50.0379 || decoded.Position.Longitude != 8.5622 {
+		t.Errorf("unexpected position %v", decoded.Position)
+	}
+	for _, missing := range []string{`{"longitude":"8.5622"}`, `{"latitude":null,"longitude":"8.5622"}`, `{"latitude":"50.0379"}`} {
+		if err := json.Unmarshal([]byte(missing), &decoded); err == nil {
+			t.Errorf("expected missing coordinate in %s to fail, got %v", missing, decoded.Position)
+		}
+	}
+}
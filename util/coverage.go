@@ -0,0 +1,91 @@
+package util
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// CoverageResponse response to check quotes coverage of a position
+type CoverageResponse struct {
+	Coverage bool `json:"coverage"`
+}
+
+// NotServiceableError no fleet serves the origin or the destination of a route
+type NotServiceableError struct {
+	Origin      *Geolocation
+	Destination *Geolocation
+}
+
+func (e *NotServiceableError) Error() string {
+	places := []string{}
+	if e.Origin != nil {
+		places = append(places, "origin "+describeGeolocation(*e.Origin))
+	}
+	if e.Destination != nil {
+		places = append(places, "destination "+describeGeolocation(*e.Destination))
+	}
+	return "not serviceable: no fleet covers " + strings.Join(places, " and ")
+}
+
+func describeGeolocation(g Geolocation) string {
+	if g.DisplayAddress != "" {
+		return g.DisplayAddress
+	}
+	return g.Position.String()
+}
+
+// CoverageCache caches coverage of positions, positions are rounded so that nearby lookups share an entry
+type CoverageCache struct {
+	ttl       time.Duration
+	precision int
+	mutex     sync.Mutex
+	entries   map[string]coverageEntry
+	// nextSweep when Set removes expired entries next, so that the cache does not grow with every position looked up
+	nextSweep time.Time
+}
+
+type coverageEntry struct {
+	covered   bool
+	expiresAt time.Time
+}
+
+// NewCoverageCache creates a cache keeping entries for ttl, positions are rounded to precision decimal places
+func NewCoverageCache(ttl time.Duration, precision int) *CoverageCache {
+	return &CoverageCache{ttl: ttl, precision: precision, entries: map[string]coverageEntry{}}
+}
+
+func (c *CoverageCache) key(p Position) string {
+	return formatCoordinate(p.Latitude, c.precision) + "," + formatCoordinate(p.Longitude, c.precision)
+}
+
+// Get returns the cached coverage of a position, ok is false if it is unknown or expired
+func (c *CoverageCache) Get(p Position, now time.Time) (covered bool, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := c.key(p)
+	e, ok := c.entries[key]
+	if !ok {
+		return false, false
+	}
+	if !e.expiresAt.After(now) {
+		delete(c.entries, key)
+		return false, false
+	}
+	return e.covered, true
+}
+
+// Set caches the coverage of a position, expired entries are removed once per ttl
+func (c *CoverageCache) Set(p Position, covered bool, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !now.Before(c.nextSweep) {
+		for key, e := range c.entries {
+			if !e.expiresAt.After(now) {
+				delete(c.entries, key)
+			}
+		}
+		c.nextSweep = now.Add(c.ttl)
+	}
+	c.entries[c.key(p)] = coverageEntry{covered: covered, expiresAt: now.Add(c.ttl)}
+}
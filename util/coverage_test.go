@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoverageCache(t *testing.T) {
+	now := time.Now()
+	c := NewCoverageCache(time.Minute, 3)
+	c.Set(Position{Latitude: 50.037933, Longitude: 8.562152}, true, now)
+
+	if covered, ok := c.Get(Position{Latitude: 50.03801, Longitude: 8.56209}, now); !ok || !covered {
+		t.Error("expected nearby position to share the cache entry")
+	}
+	if _, ok := c.Get(Position{Latitude: 50.1, Longitude: 8.56}, now); ok {
+		t.Error("expected distant position not to be cached")
+	}
+	if _, ok := c.Get(Position{Latitude: 50.037933, Longitude: 8.562152}, now.Add(time.Minute)); ok {
+		t.Error("expected entry to expire")
+	}
+}
+
+func TestCoverageCacheRemovesExpiredEntries(t *testing.T) {
+	now := time.Now()
+	c := NewCoverageCache(time.Minute, 3)
+	for i := 0; i < 10; i++ {
+		c.Set(Position{Latitude: 50 + float64(i)/100, Longitude: 8.56}, true, now)
+	}
+	c.Set(Position{Latitude: 51, Longitude: 8.56}, false, now.Add(time.Minute))
+	if len(c.entries) != 1 {
+		t.Errorf("expected only the fresh entry to be left, got %d entries", len(c.entries))
+	}
+	c.Get(Position{Latitude: 51, Longitude: 8.56}, now.Add(2*time.Minute))
+	if len(c.entries) != 0 {
+		t.Errorf("expected the expired entry to be removed when looked up, got %d entries", len(c.entries))
+	}
+}
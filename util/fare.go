@@ -0,0 +1,145 @@
+package util
+
+import "strings"
+
+// QuoteTypeMetered quote type of trips charged by the meter, the final fare can differ from the quoted price range
+const QuoteTypeMetered = "METERED"
+
+// FareStatePending state of a fare until the trip is finalised, its breakdown is empty
+const FareStatePending = "PENDING"
+
+// FareResponse response to get the fare of a booking, State is PENDING until the trip is finalised
+type FareResponse struct {
+	State     string `json:"state"`
+	Breakdown Fare   `json:"breakdown"`
+}
+
+// Final checks if the fare is finalised, fares without a state are not trusted to be final either
+func (f *FareResponse) Final() bool {
+	return f.State != "" && f.State != FareStatePending
+}
+
+// FareTolerance how far a fare may exceed the highest quoted price before it is flagged, the larger of Amount and
+// Percent of the highest quoted price applies
+type FareTolerance struct {
+	// Amount absolute tolerance in the smallest currency unit
+	Amount int
+	// Percent tolerance relative to the highest quoted price
+	Percent float64
+}
+
+// Allowed returns the highest fare accepted for a quoted high price
+func (t FareTolerance) Allowed(highPrice int) int {
+	tolerance := t.Amount
+	if relative := int(float64(highPrice) * t.Percent / 100); relative > tolerance {
+		tolerance = relative
+	}
+	return highPrice + tolerance
+}
+
+// BreakdownDifference quoted and charged value of a breakdown line item
+type BreakdownDifference struct {
+	Name       string `json:"name"`
+	Quoted     int    `json:"quoted"`
+	Charged    int    `json:"charged"`
+	Difference int    `json:"difference"`
+}
+
+// FareReconciliation fare of a trip compared to its quote
+type FareReconciliation struct {
+	BookingID string `json:"booking_id"`
+	QuoteType string `json:"quote_type"`
+	Currency  string `json:"currency"`
+	// QuoteCurrency currency of the quoted prices, a fare charged in another currency is flagged without comparing
+	// amounts
+	QuoteCurrency    string                `json:"quote_currency"`
+	CurrencyMismatch bool                  `json:"currency_mismatch"`
+	LowPrice         int                   `json:"low_price"`
+	HighPrice        int                   `json:"high_price"`
+	Fare             int                   `json:"fare"`
+	Allowed          int                   `json:"allowed"`
+	Excess           int                   `json:"excess"`
+	Flagged          bool                  `json:"flagged"`
+	Breakdown        []BreakdownDifference `json:"breakdown"`
+}
+
+// ReconcileFare compares the fare of a booking against its quoted price range. A fare in another currency than the
+// quote is flagged as a currency mismatch, its amounts are not comparable
+func ReconcileFare(d *BookingDetails, tolerance FareTolerance) FareReconciliation {
+	r := FareReconciliation{
+		BookingID:     d.ID,
+		QuoteType:     d.Quote.Type,
+		Currency:      d.Fare.Currency,
+		QuoteCurrency: d.Quote.Currency,
+		LowPrice:      d.Quote.LowPrice,
+		HighPrice:     d.Quote.HighPrice,
+		Fare:          d.Fare.Total,
+		Allowed:       tolerance.Allowed(d.Quote.HighPrice),
+	}
+	if r.Currency != "" && r.QuoteCurrency != "" && !strings.EqualFold(r.Currency, r.QuoteCurrency) {
+		r.CurrencyMismatch = true
+		r.Flagged = true
+		r.Breakdown = []BreakdownDifference{}
+		return r
+	}
+	r.Breakdown = diffBreakdowns(d.Quote.Breakdown, d.Fare.Breakdown)
+	if r.Fare > r.HighPrice {
+		r.Excess = r.Fare - r.HighPrice
+	}
+	r.Flagged = r.Fare > r.Allowed
+	return r
+}
+
+// diffBreakdowns matches line items by name, items only in one of the breakdowns count as 0 in the other
+func diffBreakdowns(quoted, charged []BreakdownItem) []BreakdownDifference {
+	diffs := []BreakdownDifference{}
+	index := map[string]int{}
+	for _, item := range quoted {
+		index[item.Name] = len(diffs)
+		diffs = append(diffs, BreakdownDifference{Name: item.Name, Quoted: item.Value})
+	}
+	for _, item := range charged {
+		i, ok := index[item.Name]
+		if !ok {
+			i = len(diffs)
+			index[item.Name] = i
+			diffs = append(diffs, BreakdownDifference{Name: item.Name})
+		}
+		diffs[i].Charged += item.Value
+	}
+	for i := range diffs {
+		diffs[i].Difference = diffs[i].Charged - diffs[i].Quoted
+	}
+	return diffs
+}
+
+// ReconciliationReport fares of several trips compared to their quotes
+type ReconciliationReport struct {
+	Tolerance FareTolerance        `json:"tolerance"`
+	Trips     []FareReconciliation `json:"trips"`
+	Flagged   []FareReconciliation `json:"flagged"`
+	// Pending IDs of the bookings whose fare is not finalised yet, they are not reconciled
+	Pending []string `json:"pending"`
+	// TotalExcess sum of the amounts charged above the highest quoted price, per currency
+	TotalExcess map[string]int `json:"total_excess"`
+}
+
+// NewReconciliationReport reconciles the fares of all bookings, pending lists the bookings whose fare is not final yet
+func NewReconciliationReport(bookings []BookingDetails, pending []string, tolerance FareTolerance) *ReconciliationReport {
+	report := &ReconciliationReport{
+		Tolerance:   tolerance,
+		Trips:       []FareReconciliation{},
+		Flagged:     []FareReconciliation{},
+		Pending:     append([]string{}, pending...),
+		TotalExcess: map[string]int{},
+	}
+	for i := range bookings {
+		r := ReconcileFare(&bookings[i], tolerance)
+		report.Trips = append(report.Trips, r)
+		report.TotalExcess[r.Currency] += r.Excess
+		if r.Flagged {
+			report.Flagged = append(report.Flagged, r)
+		}
+	}
+	return report
+}
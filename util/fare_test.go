@@ -0,0 +1,60 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestReconcileFare(t *testing.T) {
+	d := &BookingDetails{ID: "booking"}
+	d.Quote.Type = QuoteTypeMetered
+	d.Quote.LowPrice = 3000
+	d.Quote.HighPrice = 3500
+	d.Quote.Breakdown = []BreakdownItem{{Name: "Basic amount", Value: 3000}}
+	d.Fare.Currency = "EUR"
+	d.Fare.Total = 4000
+	d.Fare.Breakdown = []BreakdownItem{{Name: "Basic amount", Value: 3200}, {Name: "Waiting time", Value: 800}}
+
+	r := ReconcileFare(d, FareTolerance{Percent: 10})
+	if r.Allowed != 3850 {
+		t.Errorf("expected allowed fare 3850, got %d", r.Allowed)
+	}
+	if !r.Flagged || r.Excess != 500 {
+		t.Errorf("expected flagged fare with excess 500, got %v", r)
+	}
+	if len(r.Breakdown) != 2 || r.Breakdown[0].Difference != 200 || r.Breakdown[1].Quoted != 0 {
+		t.Errorf("unexpected breakdown differences %v", r.Breakdown)
+	}
+
+	r = ReconcileFare(d, FareTolerance{Amount: 500})
+	if r.Flagged {
+		t.Error("expected fare within absolute tolerance not to be flagged")
+	}
+
+	// a fare charged in another currency is flagged whatever the amounts
+	d.Quote.Currency = "GBP"
+	d.Fare.Total = 3000
+	r = ReconcileFare(d, FareTolerance{Percent: 10})
+	if !r.CurrencyMismatch || !r.Flagged || r.Excess != 0 || len(r.Breakdown) != 0 {
+		t.Errorf("expected a flagged currency mismatch without excess, got %+v", r)
+	}
+	d.Quote.Currency = "eur"
+	if r = ReconcileFare(d, FareTolerance{Percent: 10}); r.CurrencyMismatch || r.Flagged {
+		t.Errorf("expected the same currency in another case not to be flagged, got %+v", r)
+	}
+}
+
+func TestPendingFareNotReconciled(t *testing.T) {
+	for _, state := range []string{FareStatePending, ""} {
+		if (&FareResponse{State: state}).Final() {
+			t.Errorf("expected fare in state %q not to be final", state)
+		}
+	}
+	if !(&FareResponse{State: "FINAL"}).Final() {
+		t.Error("expected fare in state FINAL to be final")
+	}
+
+	report := NewReconciliationReport(nil, []string{"booking"}, FareTolerance{Percent: 10})
+	if len(report.Trips) != 0 || len(report.Pending) != 1 || report.Pending[0] != "booking" {
+		t.Errorf("expected the pending booking to be reported and not reconciled, got %+v", report)
+	}
+}
@@ -0,0 +1,150 @@
+package util
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// error codes of the gateway, errors karhoo returned keep karhoo's code
+const (
+	GatewayErrorUnauthorized        = "UNAUTHORIZED"
+	GatewayErrorBadRequest          = "BAD_REQUEST"
+	GatewayErrorValidation          = "VALIDATION_FAILED"
+	GatewayErrorNotFound            = "NOT_FOUND"
+	GatewayErrorMethodNotAllowed    = "METHOD_NOT_ALLOWED"
+	GatewayErrorNotServiceable      = "NOT_SERVICEABLE"
+	GatewayErrorFeeNotAccepted      = "CANCELLATION_FEE_NOT_ACCEPTED"
+	GatewayErrorApprovalDecided     = "APPROVAL_ALREADY_DECIDED"
+	GatewayErrorReapprovalRequired  = "REAPPROVAL_REQUIRED"
+	GatewayErrorUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	GatewayErrorUpstream            = "UPSTREAM_ERROR"
+	GatewayErrorInternal            = "INTERNAL_ERROR"
+)
+
+// GatewayError error response of the gateway. It has the ErrorInfo format of karhoo's own errors, so frontends parse
+// a single format whether the gateway or karhoo rejected the request
+type GatewayError struct {
+	StatusCode int `json:"-"`
+	ErrorInfo
+}
+
+// NewGatewayError creates a gateway error response
+func NewGatewayError(statusCode int, code, message string, details ...ErrorDetail) *GatewayError {
+	// frontends can always iterate the details
+	if details == nil {
+		details = []ErrorDetail{}
+	}
+	return &GatewayError{
+		StatusCode: statusCode,
+		ErrorInfo:  ErrorInfo{Code: code, Message: message, Details: details},
+	}
+}
+
+func (e *GatewayError) Error() string {
+	return e.Message
+}
+
+// GatewayErrorFrom maps an error of the client to the error response of the gateway. Calls karhoo did not answer become
+// bad gateway errors. Errors it does not know become internal errors without their message, they may contain details
+// frontends should not see
+func GatewayErrorFrom(err error) *GatewayError {
+	var gatewayError *GatewayError
+	var validationErrors ValidationErrors
+	var validationError ValidationError
+	var notServiceable *NotServiceableError
+	var circuitOpen *CircuitOpenError
+	var apiError *APIError
+	var transportError *TransportError
+	switch {
+	case errors.As(err, &gatewayError):
+		return gatewayError
+	case errors.As(err, &validationErrors):
+		details := make([]ErrorDetail, len(validationErrors))
+		for i, v := range validationErrors {
+			details[i] = ErrorDetail{Message: v.Message, Detail: v.Field}
+		}
+		return NewGatewayError(http.StatusBadRequest, GatewayErrorValidation, "invalid request", details...)
+	case errors.As(err, &validationError):
+		return NewGatewayError(http.StatusBadRequest, GatewayErrorValidation, "invalid request",
+			ErrorDetail{Message: validationError.Message, Detail: validationError.Field})
+	case errors.As(err, &notServiceable):
+		return NewGatewayError(http.StatusUnprocessableEntity, GatewayErrorNotServiceable, notServiceable.Error())
+	case errors.As(err, &circuitOpen):
+		return NewGatewayError(http.StatusServiceUnavailable, GatewayErrorUpstreamUnavailable,
+			"karhoo is unavailable, retry later",
+			ErrorDetail{Message: "retry after", Detail: circuitOpen.RetryAt.UTC().Format(time.RFC3339)})
+	case errors.As(err, &apiError):
+		switch {
+		// our karhoo credentials are not the frontend's business
+		case apiError.StatusCode == http.StatusUnauthorized || apiError.StatusCode == http.StatusForbidden:
+			return NewGatewayError(http.StatusBadGateway, GatewayErrorUpstream, "karhoo rejected the gateway's credentials")
+		case apiError.StatusCode >= 500:
+			return &GatewayError{StatusCode: http.StatusBadGateway, ErrorInfo: apiError.ErrorInfo}
+		}
+		return &GatewayError{StatusCode: apiError.StatusCode, ErrorInfo: apiError.ErrorInfo}
+	case errors.As(err, &transportError):
+		return NewGatewayError(http.StatusBadGateway, GatewayErrorUpstream, "karhoo could not be reached")
+	}
+	return NewGatewayError(http.StatusInternalServerError, GatewayErrorInternal, "internal error")
+}
+
+// APIKeys API keys of the gateway's clients. Only the sha256 hashes of the keys are kept
+type APIKeys struct {
+	clients map[[sha256.Size]byte]string
+}
+
+// NewAPIKeys creates an empty key set
+func NewAPIKeys() *APIKeys {
+	return &APIKeys{clients: map[[sha256.Size]byte]string{}}
+}
+
+// Add adds the key of a client
+func (k *APIKeys) Add(client, key string) {
+	k.clients[sha256.Sum256([]byte(key))] = client
+}
+
+// Client returns the name of the client the key belongs to, false if the key is unknown
+func (k *APIKeys) Client(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	client, ok := k.clients[sha256.Sum256([]byte(key))]
+	return client, ok
+}
+
+// Len number of keys
+func (k *APIKeys) Len() int {
+	return len(k.clients)
+}
+
+// ParseAPIKeys reads API keys, one "<client> <key>" pair per line. Empty lines and lines starting with # are skipped
+func ParseAPIKeys(r io.Reader) (*APIKeys, error) {
+	keys := NewAPIKeys()
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected <client> <key>", line)
+		}
+		if _, ok := keys.Client(fields[1]); ok {
+			return nil, fmt.Errorf("line %d: duplicate key", line)
+		}
+		keys.Add(fields[0], fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys(strings.NewReader("# frontend keys\n\nweb k3y-web\n  mobile   k3y-mobile  \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.Len() != 2 {
+		t.Errorf("expected 2 keys, got %d", keys.Len())
+	}
+	if client, ok := keys.Client("k3y-mobile"); !ok || client != "mobile" {
+		t.Errorf("expected key of mobile, got %q %v", client, ok)
+	}
+	if _, ok := keys.Client("k3y"); ok {
+		t.Error("expected unknown key to be rejected")
+	}
+	if _, ok := keys.Client(""); ok {
+		t.Error("expected empty key to be rejected")
+	}
+
+	if _, err := ParseAPIKeys(strings.NewReader("web\n")); err == nil {
+		t.Error("expected line without key to fail")
+	}
+	if _, err := ParseAPIKeys(strings.NewReader("web k\nmobile k\n")); err == nil {
+		t.Error("expected duplicate key to fail")
+	}
+}
+
+func TestGatewayErrorFrom(t *testing.T) {
+	tests := []struct {
+		err        error
+		statusCode int
+		code       string
+	}{
+		{ValidationErrors{{Field: "passengers", Message: "required"}}, 400, GatewayErrorValidation},
+		{fmt.Errorf("invalid origin: %w", ValidationError{Field: "origin", Message: "latitude out of range"}), 400, GatewayErrorValidation},
+		{&NotServiceableError{Origin: &Geolocation{DisplayAddress: "Nowhere"}}, 422, GatewayErrorNotServiceable},
+		{&CircuitOpenError{Endpoint: "POST /v2/quotes", RetryAt: time.Now()}, 503, GatewayErrorUpstreamUnavailable},
+		{NewAPIError(400, &ErrorInfo{Code: "K3001", Message: "Invalid quote"}), 400, "K3001"},
+		{NewAPIError(401, &ErrorInfo{Code: "K0001", Message: "Unauthorized"}), 502, GatewayErrorUpstream},
+		{NewAPIError(500, nil), 502, ""},
+		{NewGatewayError(404, GatewayErrorNotFound, "not found"), 404, GatewayErrorNotFound},
+		{&TransportError{Err: errors.New("dial tcp: connection refused")}, 502, GatewayErrorUpstream},
+		{errors.New("unexpected end of JSON input"), 500, GatewayErrorInternal},
+	}
+	for _, test := range tests {
+		e := GatewayErrorFrom(test.err)
+		if e.StatusCode != test.statusCode || e.Code != test.code {
+			t.Errorf("%v: expected %d %q, got %d %q", test.err, test.statusCode, test.code, e.StatusCode, e.Code)
+		}
+	}
+
+	e := GatewayErrorFrom(ValidationErrors{{Field: "passengers", Message: "required"}, {Field: "quote_id", Message: "required"}})
+	if len(e.Details) != 2 || e.Details[1].Detail != "quote_id" {
+		t.Errorf("expected a detail per invalid field, got %+v", e.Details)
+	}
+	if e := GatewayErrorFrom(errors.New("secret")); strings.Contains(e.Message, "secret") {
+		t.Error("expected unknown errors not to leak their message")
+	}
+}
@@ -0,0 +1,542 @@
+package util
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ledgerMigrations schema of the ledger, one entry per version. Applied migrations are recorded in
+// schema_migrations, never change an entry once released, append a new one instead
+var ledgerMigrations = []string{
+	// 1: quote selections, bookings with their latest status, status history and cancellations
+	`CREATE TABLE quote_selections (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		quote_id TEXT NOT NULL,
+		fleet_id TEXT NOT NULL,
+		fleet_name TEXT NOT NULL,
+		vehicle_class TEXT NOT NULL,
+		quote_type TEXT NOT NULL,
+		currency TEXT NOT NULL,
+		price_low INTEGER NOT NULL,
+		price_high INTEGER NOT NULL,
+		selected_at TEXT NOT NULL
+	);
+	CREATE INDEX quote_selections_quote_id ON quote_selections (quote_id);
+	CREATE TABLE bookings (
+		booking_id TEXT PRIMARY KEY,
+		quote_id TEXT NOT NULL,
+		display_trip_id TEXT NOT NULL,
+		partner_trip_id TEXT NOT NULL,
+		traveller_name TEXT NOT NULL,
+		traveller_phone TEXT NOT NULL,
+		traveller_email TEXT NOT NULL,
+		status TEXT NOT NULL,
+		origin TEXT NOT NULL,
+		destination TEXT NOT NULL,
+		fleet_name TEXT NOT NULL,
+		date_scheduled TEXT NOT NULL,
+		recorded_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		details TEXT NOT NULL
+	);
+	CREATE INDEX bookings_traveller_name ON bookings (traveller_name COLLATE NOCASE);
+	CREATE INDEX bookings_traveller_phone ON bookings (traveller_phone);
+	CREATE INDEX bookings_traveller_email ON bookings (traveller_email COLLATE NOCASE);
+	CREATE INDEX bookings_date_scheduled ON bookings (date_scheduled);
+	CREATE TABLE status_changes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		booking_id TEXT NOT NULL REFERENCES bookings (booking_id),
+		old_status TEXT NOT NULL,
+		new_status TEXT NOT NULL,
+		changed_at TEXT NOT NULL
+	);
+	CREATE INDEX status_changes_booking_id ON status_changes (booking_id);
+	CREATE TABLE cancellations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		booking_id TEXT NOT NULL REFERENCES bookings (booking_id),
+		reason TEXT NOT NULL,
+		fee INTEGER NOT NULL,
+		fee_currency TEXT NOT NULL,
+		cancelled_at TEXT NOT NULL
+	);
+	CREATE INDEX cancellations_booking_id ON cancellations (booking_id);`,
+	// 2: API key client that made each booking through the gateway or gRPC server
+	`CREATE TABLE booking_owners (
+		booking_id TEXT PRIMARY KEY,
+		client TEXT NOT NULL,
+		recorded_at TEXT NOT NULL
+	);`,
+	// 3: out of policy bookings waiting for approval, the request is kept as JSON
+	`CREATE TABLE approvals (
+		id TEXT PRIMARY KEY,
+		status TEXT NOT NULL,
+		client TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		request TEXT NOT NULL
+	);
+	CREATE INDEX approvals_status ON approvals (status);`,
+}
+
+// ledgerTimeFormat times are stored as UTC text in a fixed width format, so that they compare correctly as strings
+const ledgerTimeFormat = "2006-01-02T15:04:05.000Z"
+
+func formatLedgerTime(t time.Time) string {
+	return t.UTC().Format(ledgerTimeFormat)
+}
+
+func parseLedgerTime(s string) time.Time {
+	t, _ := time.Parse(ledgerTimeFormat, s)
+	return t
+}
+
+// Ledger our own record of quote selections, bookings, status changes and cancellations, independent of karhoo.
+// It works on any database/sql driver speaking SQLite, the caller opens the database
+type Ledger struct {
+	db *sql.DB
+}
+
+// LedgerStatusChange a recorded status change of a booking, the first status of a booking has an empty From
+type LedgerStatusChange struct {
+	From      BookingStatus `json:"from"`
+	To        BookingStatus `json:"to"`
+	ChangedAt time.Time     `json:"changed_at"`
+}
+
+// LedgerCancellation a recorded cancellation, Fee is 0 if cancelling was free
+type LedgerCancellation struct {
+	Reason      CancelReason `json:"reason"`
+	Fee         int          `json:"fee"`
+	FeeCurrency string       `json:"fee_currency"`
+	CancelledAt time.Time    `json:"cancelled_at"`
+}
+
+// LedgerQuoteSelection a recorded quote chosen for booking
+type LedgerQuoteSelection struct {
+	QuoteID      string    `json:"quote_id"`
+	FleetID      string    `json:"fleet_id"`
+	FleetName    string    `json:"fleet_name"`
+	VehicleClass string    `json:"vehicle_class"`
+	QuoteType    string    `json:"quote_type"`
+	Currency     string    `json:"currency"`
+	PriceLow     int       `json:"price_low"`
+	PriceHigh    int       `json:"price_high"`
+	SelectedAt   time.Time `json:"selected_at"`
+}
+
+// LedgerBooking a recorded booking with its history
+type LedgerBooking struct {
+	BookingID      string        `json:"booking_id"`
+	QuoteID        string        `json:"quote_id"`
+	DisplayTripID  string        `json:"display_trip_id"`
+	PartnerTripID  string        `json:"partner_trip_id"`
+	TravellerName  string        `json:"traveller_name" redact:"personal"`
+	TravellerPhone string        `json:"traveller_phone" redact:"personal"`
+	TravellerEmail string        `json:"traveller_email" redact:"personal"`
+	Status         BookingStatus `json:"status"`
+	Origin         string        `json:"origin"`
+	Destination    string        `json:"destination"`
+	FleetName      string        `json:"fleet_name"`
+	DateScheduled  time.Time     `json:"date_scheduled"`
+	RecordedAt     time.Time     `json:"recorded_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	// Details latest booking details as returned by karhoo
+	Details         *BookingDetails        `json:"details,omitempty"`
+	QuoteSelections []LedgerQuoteSelection `json:"quote_selections,omitempty"`
+	StatusChanges   []LedgerStatusChange   `json:"status_changes,omitempty"`
+	Cancellations   []LedgerCancellation   `json:"cancellations,omitempty"`
+}
+
+// NewLedger migrates the database to the latest schema and returns the ledger on top of it
+func NewLedger(db *sql.DB) (*Ledger, error) {
+	l := &Ledger{db: db}
+	err := l.migrate()
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SchemaVersion number of migrations applied to the database
+func (l *Ledger) SchemaVersion() (int, error) {
+	var version int
+	err := l.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// migrate applies every migration newer than the schema version, each in its own transaction
+func (l *Ledger) migrate() error {
+	_, err := l.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`)
+	if err != nil {
+		return err
+	}
+	version, err := l.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version > len(ledgerMigrations) {
+		return fmt.Errorf("ledger schema version %d is newer than this program, which knows %d", version, len(ledgerMigrations))
+	}
+	for i := version; i < len(ledgerMigrations); i++ {
+		tx, err := l.db.Begin()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ledgerMigrations[i])
+		if err == nil {
+			_, err = tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, i+1, formatLedgerTime(time.Now()))
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("ledger migration %d: %w", i+1, err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the database
+func (l *Ledger) Close() error {
+	return l.db.Close()
+}
+
+// RecordQuoteSelection records the quote chosen for a booking
+func (l *Ledger) RecordQuoteSelection(q Quote, at time.Time) error {
+	_, err := l.db.Exec(`INSERT INTO quote_selections
+		(quote_id, fleet_id, fleet_name, vehicle_class, quote_type, currency, price_low, price_high, selected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		q.ID, q.Fleet.ID, q.Fleet.Name, q.Vehicle.Class, q.QuoteType, q.Price.CurrencyCode, q.Price.Low, q.Price.High,
+		formatLedgerTime(at))
+	return err
+}
+
+// RecordBooking records a booking response or a later lookup of the booking. A status differing from the recorded
+// one is added to the status history. quoteID may be empty for lookups, the recorded quote ID is kept then
+func (l *Ledger) RecordBooking(d *BookingDetails, quoteID string, at time.Time) error {
+	details, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	var passenger Passenger
+	if len(d.Passengers.PassengerDetails) > 0 {
+		passenger = d.Passengers.PassengerDetails[0]
+	}
+	tx, err := l.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous string
+	err = tx.QueryRow(`SELECT status FROM bookings WHERE booking_id = ?`, d.ID).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO bookings
+		(booking_id, quote_id, display_trip_id, partner_trip_id, traveller_name, traveller_phone, traveller_email, status,
+		origin, destination, fleet_name, date_scheduled, recorded_at, updated_at, details)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (booking_id) DO UPDATE SET
+		quote_id = CASE WHEN excluded.quote_id = '' THEN bookings.quote_id ELSE excluded.quote_id END,
+		display_trip_id = excluded.display_trip_id,
+		partner_trip_id = excluded.partner_trip_id,
+		traveller_name = excluded.traveller_name,
+		traveller_phone = excluded.traveller_phone,
+		traveller_email = excluded.traveller_email,
+		status = excluded.status,
+		origin = excluded.origin,
+		destination = excluded.destination,
+		fleet_name = excluded.fleet_name,
+		date_scheduled = excluded.date_scheduled,
+		updated_at = excluded.updated_at,
+		details = excluded.details`,
+		d.ID, quoteID, d.DisplayTripID, d.PartnerTripID,
+		strings.TrimSpace(passenger.FirstName+" "+passenger.LastName), passenger.PhoneNumber, passenger.Email,
+		string(d.Status), d.Origin.DisplayAddress, d.Destination.DisplayAddress, d.FleetInfo.Name,
+		formatLedgerTime(d.DateScheduled), formatLedgerTime(at), formatLedgerTime(at), string(details))
+	if err != nil {
+		return err
+	}
+	if previous != string(d.Status) {
+		_, err = tx.Exec(`INSERT INTO status_changes (booking_id, old_status, new_status, changed_at) VALUES (?, ?, ?, ?)`,
+			d.ID, previous, string(d.Status), formatLedgerTime(at))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RecordCancellation records a cancelled booking, fee is nil if it was not checked. The booking's status moves to
+// BOOKER_CANCELLED in the same transaction. Bookings the ledger does not know yet are recorded with their ID only
+func (l *Ledger) RecordCancellation(bookingID string, reason CancelReason, fee *CancellationFee, at time.Time) error {
+	amount, currency := 0, ""
+	if fee != nil && fee.Applies() {
+		amount, currency = fee.Fee.Value, fee.Fee.Currency
+	}
+	tx, err := l.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous string
+	details := "null"
+	err = tx.QueryRow(`SELECT status, details FROM bookings WHERE booking_id = ?`, bookingID).Scan(&previous, &details)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	// the stored details should not contradict the status column
+	if details != "null" {
+		var d BookingDetails
+		err = json.Unmarshal([]byte(details), &d)
+		if err != nil {
+			return err
+		}
+		d.Status = BookingBookerCancelled
+		b, err := json.Marshal(&d)
+		if err != nil {
+			return err
+		}
+		details = string(b)
+	}
+	_, err = tx.Exec(`INSERT INTO bookings
+		(booking_id, quote_id, display_trip_id, partner_trip_id, traveller_name, traveller_phone, traveller_email, status,
+		origin, destination, fleet_name, date_scheduled, recorded_at, updated_at, details)
+		VALUES (?, '', '', '', '', '', '', ?, '', '', '', '', ?, ?, ?)
+		ON CONFLICT (booking_id) DO UPDATE SET
+		status = excluded.status,
+		updated_at = excluded.updated_at,
+		details = excluded.details`,
+		bookingID, string(BookingBookerCancelled), formatLedgerTime(at), formatLedgerTime(at), details)
+	if err != nil {
+		return err
+	}
+	if previous != string(BookingBookerCancelled) {
+		_, err = tx.Exec(`INSERT INTO status_changes (booking_id, old_status, new_status, changed_at) VALUES (?, ?, ?, ?)`,
+			bookingID, previous, string(BookingBookerCancelled), formatLedgerTime(at))
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec(`INSERT INTO cancellations (booking_id, reason, fee, fee_currency, cancelled_at) VALUES (?, ?, ?, ?, ?)`,
+		bookingID, string(reason), amount, currency, formatLedgerTime(at))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecordBookingOwner records the API key client that made a booking, recording another owner replaces it
+func (l *Ledger) RecordBookingOwner(bookingID, client string, at time.Time) error {
+	_, err := l.db.Exec(`INSERT INTO booking_owners (booking_id, client, recorded_at) VALUES (?, ?, ?)
+		ON CONFLICT (booking_id) DO UPDATE SET client = excluded.client, recorded_at = excluded.recorded_at`,
+		bookingID, client, formatLedgerTime(at))
+	return err
+}
+
+// BookingOwner returns the API key client that made a booking, empty if no owner was recorded
+func (l *Ledger) BookingOwner(bookingID string) (string, error) {
+	var client string
+	err := l.db.QueryRow(`SELECT client FROM booking_owners WHERE booking_id = ?`, bookingID).Scan(&client)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return client, err
+}
+
+// RecordApproval records a new approval request
+func (l *Ledger) RecordApproval(r *ApprovalRequest) error {
+	request, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = l.db.Exec(`INSERT INTO approvals (id, status, client, created_at, request) VALUES (?, ?, ?, ?, ?)`,
+		r.ID, string(r.Status), r.Client, formatLedgerTime(r.CreatedAt), string(request))
+	return err
+}
+
+// UpdateApproval records the changes of an approval request if its recorded status is still from. It returns false
+// if the request changed status in the meantime, e.g. because another process decided on it first
+func (l *Ledger) UpdateApproval(r *ApprovalRequest, from ApprovalStatus) (bool, error) {
+	request, err := json.Marshal(r)
+	if err != nil {
+		return false, err
+	}
+	res, err := l.db.Exec(`UPDATE approvals SET status = ?, request = ? WHERE id = ? AND status = ?`,
+		string(r.Status), string(request), r.ID, string(from))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// Approval returns an approval request, nil if the ledger does not know it
+func (l *Ledger) Approval(id string) (*ApprovalRequest, error) {
+	approvals, err := l.queryApprovals(`SELECT request FROM approvals WHERE id = ?`, id)
+	if err != nil || len(approvals) == 0 {
+		return nil, err
+	}
+	return approvals[0], nil
+}
+
+// Approvals returns the approval requests with the given status, oldest first
+func (l *Ledger) Approvals(status ApprovalStatus) ([]*ApprovalRequest, error) {
+	return l.queryApprovals(`SELECT request FROM approvals WHERE status = ? ORDER BY created_at, id`, string(status))
+}
+
+func (l *Ledger) queryApprovals(query string, args ...interface{}) ([]*ApprovalRequest, error) {
+	rows, err := l.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	approvals := []*ApprovalRequest{}
+	for rows.Next() {
+		var request string
+		err = rows.Scan(&request)
+		if err != nil {
+			return nil, err
+		}
+		var r ApprovalRequest
+		err = json.Unmarshal([]byte(request), &r)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, &r)
+	}
+	return approvals, rows.Err()
+}
+
+const ledgerBookingColumns = `booking_id, quote_id, display_trip_id, partner_trip_id, traveller_name, traveller_phone,
+	traveller_email, status, origin, destination, fleet_name, date_scheduled, recorded_at, updated_at, details`
+
+// Booking returns a recorded booking with its quote selections, status history and cancellations, nil if the ledger
+// does not know it
+func (l *Ledger) Booking(bookingID string) (*LedgerBooking, error) {
+	bookings, err := l.queryBookings(`SELECT `+ledgerBookingColumns+` FROM bookings WHERE booking_id = ?`, bookingID)
+	if err != nil || len(bookings) == 0 {
+		return nil, err
+	}
+	b := &bookings[0]
+	err = l.loadHistory(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// BookingsByTraveller returns the bookings of a traveller, matched by full name or email ignoring case, or by phone
+// number, most recently scheduled first
+func (l *Ledger) BookingsByTraveller(traveller string) ([]LedgerBooking, error) {
+	traveller = strings.TrimSpace(traveller)
+	return l.queryBookings(`SELECT `+ledgerBookingColumns+` FROM bookings
+		WHERE traveller_name = ? COLLATE NOCASE OR traveller_email = ? COLLATE NOCASE OR traveller_phone = ?
+		ORDER BY date_scheduled DESC`, traveller, traveller, traveller)
+}
+
+// BookingsScheduledBetween returns the bookings with a pickup in [from, to), earliest first
+func (l *Ledger) BookingsScheduledBetween(from, to time.Time) ([]LedgerBooking, error) {
+	return l.queryBookings(`SELECT `+ledgerBookingColumns+` FROM bookings
+		WHERE date_scheduled >= ? AND date_scheduled < ? ORDER BY date_scheduled`,
+		formatLedgerTime(from), formatLedgerTime(to))
+}
+
+func (l *Ledger) queryBookings(query string, args ...interface{}) ([]LedgerBooking, error) {
+	rows, err := l.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	bookings := []LedgerBooking{}
+	for rows.Next() {
+		var b LedgerBooking
+		var status, dateScheduled, recordedAt, updatedAt, details string
+		err = rows.Scan(&b.BookingID, &b.QuoteID, &b.DisplayTripID, &b.PartnerTripID, &b.TravellerName, &b.TravellerPhone,
+			&b.TravellerEmail, &status, &b.Origin, &b.Destination, &b.FleetName, &dateScheduled, &recordedAt, &updatedAt, &details)
+		if err != nil {
+			return nil, err
+		}
+		b.Status = BookingStatus(status)
+		b.DateScheduled = parseLedgerTime(dateScheduled)
+		b.RecordedAt = parseLedgerTime(recordedAt)
+		b.UpdatedAt = parseLedgerTime(updatedAt)
+		err = json.Unmarshal([]byte(details), &b.Details)
+		if err != nil {
+			return nil, fmt.Errorf("booking %s: %w", b.BookingID, err)
+		}
+		bookings = append(bookings, b)
+	}
+	return bookings, rows.Err()
+}
+
+// loadHistory loads the quote selections, status changes and cancellations of a booking
+func (l *Ledger) loadHistory(b *LedgerBooking) error {
+	if b.QuoteID != "" {
+		rows, err := l.db.Query(`SELECT quote_id, fleet_id, fleet_name, vehicle_class, quote_type, currency, price_low,
+			price_high, selected_at FROM quote_selections WHERE quote_id = ? ORDER BY id`, b.QuoteID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var s LedgerQuoteSelection
+			var selectedAt string
+			err = rows.Scan(&s.QuoteID, &s.FleetID, &s.FleetName, &s.VehicleClass, &s.QuoteType, &s.Currency, &s.PriceLow,
+				&s.PriceHigh, &selectedAt)
+			if err != nil {
+				return err
+			}
+			s.SelectedAt = parseLedgerTime(selectedAt)
+			b.QuoteSelections = append(b.QuoteSelections, s)
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	rows, err := l.db.Query(`SELECT old_status, new_status, changed_at FROM status_changes WHERE booking_id = ? ORDER BY id`,
+		b.BookingID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var from, to, changedAt string
+		err = rows.Scan(&from, &to, &changedAt)
+		if err != nil {
+			return err
+		}
+		b.StatusChanges = append(b.StatusChanges, LedgerStatusChange{
+			From: BookingStatus(from), To: BookingStatus(to), ChangedAt: parseLedgerTime(changedAt),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = l.db.Query(`SELECT reason, fee, fee_currency, cancelled_at FROM cancellations WHERE booking_id = ? ORDER BY id`,
+		b.BookingID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c LedgerCancellation
+		var reason, cancelledAt string
+		err = rows.Scan(&reason, &c.Fee, &c.FeeCurrency, &cancelledAt)
+		if err != nil {
+			return err
+		}
+		c.Reason = CancelReason(reason)
+		c.CancelledAt = parseLedgerTime(cancelledAt)
+		b.Cancellations = append(b.Cancellations, c)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,215 @@
+package util
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestLedger(t *testing.T) *Ledger {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection to :memory: is a database of its own
+	db.SetMaxOpenConns(1)
+	l, err := NewLedger(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func ledgerTestBooking(id, firstName, lastName string, status BookingStatus, scheduled time.Time) *BookingDetails {
+	d := &BookingDetails{ID: id, Status: status, DateScheduled: scheduled, DisplayTripID: "T-" + id}
+	d.Passengers.PassengerDetails = []Passenger{{FirstName: firstName, LastName: lastName, PhoneNumber: "+15005550006", Email: "john.smith@example.com"}}
+	d.Origin.DisplayAddress = "Frankfurt Airport"
+	d.Destination.DisplayAddress = "Frankfurt Hbf"
+	return d
+}
+
+func TestLedgerMigrations(t *testing.T) {
+	l := newTestLedger(t)
+	defer l.Close()
+	version, err := l.SchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != len(ledgerMigrations) {
+		t.Errorf("expected schema version %d, got %d", len(ledgerMigrations), version)
+	}
+	// migrating again is a no-op
+	if err := l.migrate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLedgerBookingHistory(t *testing.T) {
+	l := newTestLedger(t)
+	defer l.Close()
+	now := time.Date(2021, 1, 8, 9, 0, 0, 0, time.UTC)
+	scheduled := now.Add(time.Hour)
+
+	var q Quote
+	q.ID = "list-1:quote-1"
+	q.Fleet.Name = "Global PHV"
+	q.Vehicle.Class = "Saloon"
+	q.Price.Low, q.Price.High, q.Price.CurrencyCode = 1711, 1711, "GBP"
+	if err := l.RecordQuoteSelection(q, now); err != nil {
+		t.Fatal(err)
+	}
+	d := ledgerTestBooking("booking-1", "John", "Smith", BookingRequested, scheduled)
+	if err := l.RecordBooking(d, q.ID, now); err != nil {
+		t.Fatal(err)
+	}
+	// looking the booking up again without a status change adds no history
+	if err := l.RecordBooking(d, "", now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	d.Status = BookingConfirmed
+	if err := l.RecordBooking(d, "", now.Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	fee := &CancellationFee{CancellationFee: true}
+	fee.Fee.Value, fee.Fee.Currency = 500, "GBP"
+	if err := l.RecordCancellation(d.ID, CancelNotNeededAnymore, fee, now.Add(3*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := l.Booking("booking-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b == nil {
+		t.Fatal("expected booking to be recorded")
+	}
+	if b.QuoteID != q.ID || b.Status != BookingBookerCancelled || b.TravellerName != "John Smith" || !b.DateScheduled.Equal(scheduled) {
+		t.Errorf("unexpected booking %+v", b)
+	}
+	if b.Details == nil || b.Details.DisplayTripID != "T-booking-1" || b.Details.Status != BookingBookerCancelled {
+		t.Errorf("expected booking details to be kept, got %+v", b.Details)
+	}
+	if len(b.QuoteSelections) != 1 || b.QuoteSelections[0].PriceHigh != 1711 {
+		t.Errorf("expected the quote selection, got %+v", b.QuoteSelections)
+	}
+	if len(b.StatusChanges) != 3 || b.StatusChanges[0].From != "" || b.StatusChanges[1].From != BookingRequested ||
+		b.StatusChanges[1].To != BookingConfirmed || b.StatusChanges[2].To != BookingBookerCancelled {
+		t.Errorf("expected status history REQUESTED -> CONFIRMED -> BOOKER_CANCELLED, got %+v", b.StatusChanges)
+	}
+	if len(b.Cancellations) != 1 || b.Cancellations[0].Fee != 500 || b.Cancellations[0].Reason != CancelNotNeededAnymore {
+		t.Errorf("expected the cancellation with its fee, got %+v", b.Cancellations)
+	}
+
+	// cancelling a booking the ledger does not know records it as cancelled
+	if err := l.RecordCancellation("booking-2", CancelNotNeededAnymore, nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := l.Booking("booking-2"); err != nil || b == nil || b.Status != BookingBookerCancelled || len(b.StatusChanges) != 1 {
+		t.Errorf("expected the unknown booking to be recorded as cancelled, got %+v %v", b, err)
+	}
+
+	if b, err := l.Booking("unknown"); err != nil || b != nil {
+		t.Errorf("expected unknown booking to be nil, got %+v %v", b, err)
+	}
+}
+
+func TestLedgerQueries(t *testing.T) {
+	l := newTestLedger(t)
+	defer l.Close()
+	now := time.Date(2021, 1, 8, 9, 0, 0, 0, time.UTC)
+	for i, d := range []*BookingDetails{
+		ledgerTestBooking("booking-1", "John", "Smith", BookingConfirmed, now),
+		ledgerTestBooking("booking-2", "John", "Smith", BookingConfirmed, now.AddDate(0, 0, 1)),
+		ledgerTestBooking("booking-3", "Jane", "Doe", BookingConfirmed, now.AddDate(0, 0, 1).Add(time.Hour)),
+	} {
+		if i == 2 {
+			d.Passengers.PassengerDetails[0].Email = "jane.doe@example.com"
+			d.Passengers.PassengerDetails[0].PhoneNumber = "+15005550007"
+		}
+		if err := l.RecordBooking(d, "", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bookings, err := l.BookingsByTraveller("john smith")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookings) != 2 || bookings[0].BookingID != "booking-2" {
+		t.Errorf("expected John Smith's bookings, latest first, got %+v", bookings)
+	}
+	if bookings, _ := l.BookingsByTraveller("JANE.DOE@example.com"); len(bookings) != 1 {
+		t.Errorf("expected to find bookings by email, got %d", len(bookings))
+	}
+	if bookings, _ := l.BookingsByTraveller("+15005550007"); len(bookings) != 1 {
+		t.Errorf("expected to find bookings by phone number, got %d", len(bookings))
+	}
+
+	// a pickup in another timezone on the same instant is found as well
+	from := now.AddDate(0, 0, 1).In(time.FixedZone("CET", 3600))
+	bookings, err = l.BookingsScheduledBetween(from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookings) != 2 || bookings[0].BookingID != "booking-2" || bookings[1].BookingID != "booking-3" {
+		t.Errorf("expected the bookings of the next day, got %+v", bookings)
+	}
+}
+
+func TestLedgerBookingOwner(t *testing.T) {
+	l := newTestLedger(t)
+	defer l.Close()
+	now := time.Date(2021, 1, 8, 9, 0, 0, 0, time.UTC)
+	if owner, err := l.BookingOwner("booking-1"); err != nil || owner != "" {
+		t.Errorf("expected no owner yet, got %q %v", owner, err)
+	}
+	if err := l.RecordBookingOwner("booking-1", "frontend", now); err != nil {
+		t.Fatal(err)
+	}
+	if owner, err := l.BookingOwner("booking-1"); err != nil || owner != "frontend" {
+		t.Errorf("expected owner frontend, got %q %v", owner, err)
+	}
+}
+
+func TestLedgerApprovals(t *testing.T) {
+	l := newTestLedger(t)
+	defer l.Close()
+	now := time.Date(2021, 1, 8, 9, 0, 0, 0, time.UTC)
+	r := &ApprovalRequest{ID: "approval-1", Status: ApprovalPending, Client: "frontend", QuoteID: "list:quote",
+		Violations: []string{"price 9000 exceeds maximum 5000"}, CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+	if err := l.RecordApproval(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RecordApproval(&ApprovalRequest{ID: "approval-2", Status: ApprovalPending, CreatedAt: now.Add(time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := l.Approvals(ApprovalPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].ID != "approval-1" || pending[0].Client != "frontend" || len(pending[0].Violations) != 1 {
+		t.Errorf("expected both pending approvals, oldest first, got %+v", pending)
+	}
+
+	// only one of two deciders racing for the same request wins
+	r.Status = ApprovalInProgress
+	if ok, err := l.UpdateApproval(r, ApprovalPending); err != nil || !ok {
+		t.Fatalf("expected the first decider to take the request, got %v %v", ok, err)
+	}
+	if ok, err := l.UpdateApproval(r, ApprovalPending); err != nil || ok {
+		t.Errorf("expected the second decider to find the request taken, got %v %v", ok, err)
+	}
+	r.Status, r.BookingID = ApprovalApproved, "booking-1"
+	if ok, err := l.UpdateApproval(r, ApprovalInProgress); err != nil || !ok {
+		t.Fatalf("expected the decision to be recorded, got %v %v", ok, err)
+	}
+	approved, err := l.Approval("approval-1")
+	if err != nil || approved == nil || approved.Status != ApprovalApproved || approved.BookingID != "booking-1" {
+		t.Errorf("expected the approved request, got %+v %v", approved, err)
+	}
+	if missing, err := l.Approval("approval-3"); err != nil || missing != nil {
+		t.Errorf("expected no approval request, got %+v %v", missing, err)
+	}
+}
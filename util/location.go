@@ -0,0 +1,52 @@
+package util
+
+// AddressAutocompleteRequest request body to get address suggestions
+type AddressAutocompleteRequest struct {
+	Query string `json:"query"`
+	// Position optional position to bias suggestions towards
+	Position *Position `json:"position,omitempty"`
+	// Radius in meters around Position
+	Radius int `json:"radius,omitempty"`
+	// SessionToken groups autocomplete and place details calls of one lookup
+	SessionToken string `json:"session_token"`
+}
+
+// AddressSuggestion a place matching a partial address
+type AddressSuggestion struct {
+	PlaceID        string `json:"place_id"`
+	DisplayAddress string `json:"display_address"`
+	Type           string `json:"type"`
+}
+
+// AddressAutocompleteResults places matching a partial address
+type AddressAutocompleteResults struct {
+	Locations []AddressSuggestion `json:"locations"`
+}
+
+// PlaceDetails position and address of a place
+type PlaceDetails struct {
+	PlaceID  string   `json:"place_id"`
+	Position Position `json:"position"`
+	Address  struct {
+		DisplayAddress string `json:"display_address"`
+		BuildingNumber string `json:"building_number"`
+		StreetName     string `json:"street_name"`
+		City           string `json:"city"`
+		PostalCode     string `json:"postal_code"`
+		Region         string `json:"region"`
+		CountryCode    string `json:"country_code"`
+	} `json:"address"`
+	PoiType  string `json:"poi_type"`
+	Timezone string `json:"timezone"`
+}
+
+// Geolocation converts place details to a geolocation that can be used to request quotes
+func (p *PlaceDetails) Geolocation() Geolocation {
+	return Geolocation{
+		Position:       p.Position,
+		DisplayAddress: p.Address.DisplayAddress,
+		PlaceID:        p.PlaceID,
+		PoiType:        p.PoiType,
+		Timezone:       p.Timezone,
+	}
+}
@@ -0,0 +1,261 @@
+package util
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogLevel severity of a log event
+type LogLevel int
+
+const (
+	// LevelDebug every karhoo call
+	LevelDebug LogLevel = iota
+	// LevelInfo token refreshes, webhooks, bookings and servers starting
+	LevelInfo
+	// LevelWarn failures the client recovers from, e.g. by retrying
+	LevelWarn
+	// LevelError failures reported to the caller
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return strconv.Itoa(int(l))
+}
+
+// ParseLogLevel parses debug, info, warn or error
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q, use debug, info, warn or error", s)
+}
+
+// Field key and value of a log event
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// LogField creates a field of a log event
+func LogField(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger receives the log events of the client, use one of the adapters to hand them to the application's logger
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...Field)
+}
+
+// Log logger the client's events go to, nil drops them
+var Log Logger = NewStdLogger(nil, LevelInfo)
+
+// LogEvent sends an event to Log. Struct, map and slice values are logged as json redacted with LogRedaction
+func LogEvent(level LogLevel, msg string, fields ...Field) {
+	if Log == nil {
+		return
+	}
+	redacted := make([]Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = Field{Key: f.Key, Value: redactFieldValue(f.Value)}
+	}
+	Log.Log(level, msg, redacted...)
+}
+
+// redactFieldValue redacts a field value before it is logged. Values with fields tagged as sensitive are redacted
+// even when they implement fmt.Stringer, their String method could print the fields unmasked
+func redactFieldValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, error, time.Time:
+		return v
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := v.(fmt.Stringer); ok && !hasSensitiveFields(t, map[reflect.Type]bool{}) {
+		return s.String()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		redacted, err := Redact(v, LogRedaction)
+		if err != nil {
+			return "[UNLOGGABLE]"
+		}
+		return string(redacted)
+	}
+	return v
+}
+
+// LogFields correlation ID and actor of the calls made with the auth info, for the log events about them
+func (a *AuthInfo) LogFields() []Field {
+	var fields []Field
+	if a == nil {
+		return fields
+	}
+	if a.CorrelationID != "" {
+		fields = append(fields, LogField("correlation_id", a.CorrelationID))
+	}
+	if a.Actor != "" {
+		fields = append(fields, LogField("actor", a.Actor))
+	}
+	return fields
+}
+
+// StdLogger writes events as key=value lines to a standard library logger
+type StdLogger struct {
+	logger   *log.Logger
+	minLevel LogLevel
+}
+
+// NewStdLogger creates a logger writing events of at least minLevel to l, nil writes to the standard logger of the
+// log package
+func NewStdLogger(l *log.Logger, minLevel LogLevel) *StdLogger {
+	return &StdLogger{logger: l, minLevel: minLevel}
+}
+
+// Log writes the event if its level is high enough
+func (l *StdLogger) Log(level LogLevel, msg string, fields ...Field) {
+	if level < l.minLevel {
+		return
+	}
+	var b strings.Builder
+	b.WriteString("level=" + level.String() + " msg=" + logfmtValue(msg))
+	for _, f := range fields {
+		b.WriteString(" " + f.Key + "=" + logfmtValue(f.Value))
+	}
+	if l.logger == nil {
+		log.Println(b.String())
+		return
+	}
+	l.logger.Println(b.String())
+}
+
+// logfmtValue formats a value, quoted if it contains spaces, quotes or equal signs
+func logfmtValue(v interface{}) string {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case time.Time:
+		s = v.UTC().Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// KeyValueLogger leveled logger taking alternating keys and values, e.g. *slog.Logger or hclog.Logger
+type KeyValueLogger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+type keyValueAdapter struct {
+	logger KeyValueLogger
+}
+
+// NewKeyValueLogger adapts a logger taking alternating keys and values like *slog.Logger, its handler decides which
+// levels are written
+func NewKeyValueLogger(l KeyValueLogger) Logger {
+	return keyValueAdapter{logger: l}
+}
+
+func (a keyValueAdapter) Log(level LogLevel, msg string, fields ...Field) {
+	keysAndValues := keysAndValues(fields)
+	switch {
+	case level >= LevelError:
+		a.logger.Error(msg, keysAndValues...)
+	case level == LevelWarn:
+		a.logger.Warn(msg, keysAndValues...)
+	case level == LevelInfo:
+		a.logger.Info(msg, keysAndValues...)
+	default:
+		a.logger.Debug(msg, keysAndValues...)
+	}
+}
+
+// SugaredLogger leveled logger with the "w" methods of zap's *SugaredLogger
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+type sugaredAdapter struct {
+	logger SugaredLogger
+}
+
+// NewSugaredLogger adapts a zap *SugaredLogger, its core decides which levels are written
+func NewSugaredLogger(l SugaredLogger) Logger {
+	return sugaredAdapter{logger: l}
+}
+
+func (a sugaredAdapter) Log(level LogLevel, msg string, fields ...Field) {
+	keysAndValues := keysAndValues(fields)
+	switch {
+	case level >= LevelError:
+		a.logger.Errorw(msg, keysAndValues...)
+	case level == LevelWarn:
+		a.logger.Warnw(msg, keysAndValues...)
+	case level == LevelInfo:
+		a.logger.Infow(msg, keysAndValues...)
+	default:
+		a.logger.Debugw(msg, keysAndValues...)
+	}
+}
+
+// KitLogger logger taking alternating keys and values only, e.g. go-kit's log.Logger
+type KitLogger interface {
+	Log(keyvals ...interface{}) error
+}
+
+type kitAdapter struct {
+	logger KitLogger
+}
+
+// NewKitLogger adapts a go-kit style logger, the level and message are logged as "level" and "msg"
+func NewKitLogger(l KitLogger) Logger {
+	return kitAdapter{logger: l}
+}
+
+func (a kitAdapter) Log(level LogLevel, msg string, fields ...Field) {
+	a.logger.Log(append([]interface{}{"level", level.String(), "msg", msg}, keysAndValues(fields)...)...)
+}
+
+func keysAndValues(fields []Field) []interface{} {
+	keysAndValues := make([]interface{}, 0, 2*len(fields))
+	for _, f := range fields {
+		keysAndValues = append(keysAndValues, f.Key, f.Value)
+	}
+	return keysAndValues
+}
@@ -0,0 +1,160 @@
+package util
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordedEvent one event kept by a recordingLogger
+type recordedEvent struct {
+	level  LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	events []recordedEvent
+}
+
+func (l *recordingLogger) Log(level LogLevel, msg string, fields ...Field) {
+	e := recordedEvent{level: level, msg: msg, fields: map[string]interface{}{}}
+	for _, f := range fields {
+		e.fields[f.Key] = f.Value
+	}
+	l.events = append(l.events, e)
+}
+
+// useRecordingLogger sends the events to a recordingLogger until the returned func restores Log
+func useRecordingLogger() (*recordingLogger, func()) {
+	previous := Log
+	recorder := &recordingLogger{}
+	Log = recorder
+	return recorder, func() { Log = previous }
+}
+
+func TestStdLogger(t *testing.T) {
+	var b bytes.Buffer
+	l := NewStdLogger(log.New(&b, "", 0), LevelInfo)
+	l.Log(LevelDebug, "karhoo call")
+	l.Log(LevelInfo, "access token refreshed", LogField("correlation_id", "c-1"), LogField("expires_in", 3600),
+		LogField("error", "token expired"))
+	expected := "level=info msg=\"access token refreshed\" correlation_id=c-1 expires_in=3600 error=\"token expired\"\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestLogEventRedactsStructs(t *testing.T) {
+	recorder, restore := useRecordingLogger()
+	defer restore()
+	LogEvent(LevelInfo, "passenger", LogField("passenger", Passenger{FirstName: "John", Locale: "en-GB"}),
+		LogField("status", BookingConfirmed))
+	passenger, _ := recorder.events[0].fields["passenger"].(string)
+	if strings.Contains(passenger, "John") || !strings.Contains(passenger, "en-GB") {
+		t.Errorf("expected the passenger name to be masked, got %s", passenger)
+	}
+	if recorder.events[0].fields["status"] != BookingConfirmed {
+		t.Errorf("expected plain values to be kept, got %v", recorder.events[0].fields["status"])
+	}
+}
+
+// stringerPassenger a passenger printing its name when formatted
+type stringerPassenger struct {
+	Passenger
+}
+
+func (p stringerPassenger) String() string {
+	return p.FirstName + " " + p.LastName
+}
+
+func TestLogEventRedactsStringers(t *testing.T) {
+	recorder, restore := useRecordingLogger()
+	defer restore()
+	LogEvent(LevelInfo, "passenger", LogField("passenger", stringerPassenger{Passenger{FirstName: "John", Locale: "en-GB"}}),
+		LogField("level", LevelWarn))
+	passenger, _ := recorder.events[0].fields["passenger"].(string)
+	if strings.Contains(passenger, "John") || !strings.Contains(passenger, "en-GB") {
+		t.Errorf("expected the passenger to be redacted instead of formatted, got %s", passenger)
+	}
+	if recorder.events[0].fields["level"] != "warn" {
+		t.Errorf("expected stringers without sensitive fields to be formatted, got %v", recorder.events[0].fields["level"])
+	}
+}
+
+// levelLogger records the method called by an adapter
+type levelLogger struct {
+	called        string
+	keysAndValues []interface{}
+}
+
+func (l *levelLogger) Debug(msg string, kv ...interface{})  { l.called, l.keysAndValues = "debug", kv }
+func (l *levelLogger) Info(msg string, kv ...interface{})   { l.called, l.keysAndValues = "info", kv }
+func (l *levelLogger) Warn(msg string, kv ...interface{})   { l.called, l.keysAndValues = "warn", kv }
+func (l *levelLogger) Error(msg string, kv ...interface{})  { l.called, l.keysAndValues = "error", kv }
+func (l *levelLogger) Debugw(msg string, kv ...interface{}) { l.called, l.keysAndValues = "debug", kv }
+func (l *levelLogger) Infow(msg string, kv ...interface{})  { l.called, l.keysAndValues = "info", kv }
+func (l *levelLogger) Warnw(msg string, kv ...interface{})  { l.called, l.keysAndValues = "warn", kv }
+func (l *levelLogger) Errorw(msg string, kv ...interface{}) { l.called, l.keysAndValues = "error", kv }
+
+func (l *levelLogger) Log(kv ...interface{}) error {
+	l.called, l.keysAndValues = "log", kv
+	return nil
+}
+
+func TestLoggerAdapters(t *testing.T) {
+	target := &levelLogger{}
+	for name, adapter := range map[string]Logger{"key value": NewKeyValueLogger(target), "sugared": NewSugaredLogger(target)} {
+		for _, level := range []LogLevel{LevelDebug, LevelInfo, LevelWarn, LevelError} {
+			adapter.Log(level, "karhoo call", LogField("booking_id", "booking-1"))
+			if target.called != level.String() || len(target.keysAndValues) != 2 || target.keysAndValues[0] != "booking_id" {
+				t.Errorf("%s adapter: expected %s with the booking id, got %s %v", name, level, target.called, target.keysAndValues)
+			}
+		}
+	}
+	NewKitLogger(target).Log(LevelWarn, "karhoo call failed", LogField("status", 502))
+	expected := []interface{}{"level", "warn", "msg", "karhoo call failed", "status", 502}
+	if len(target.keysAndValues) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, target.keysAndValues)
+	}
+	for i := range expected {
+		if target.keysAndValues[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, target.keysAndValues)
+		}
+	}
+}
+
+func TestRequestsAreLogged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	recorder, restore := useRecordingLogger()
+	defer restore()
+
+	res, err := GetRequest(server.URL+"/v1/bookings/booking-1", &AuthInfo{CorrelationID: "c-1", Actor: "frontend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if len(recorder.events) != 1 {
+		t.Fatalf("expected one event, got %+v", recorder.events)
+	}
+	e := recorder.events[0]
+	if e.level != LevelWarn || e.fields["endpoint"] != "GET /v1/bookings/:id" || e.fields["status"] != http.StatusBadGateway ||
+		e.fields["correlation_id"] != "c-1" || e.fields["actor"] != "frontend" {
+		t.Errorf("expected a warning about the failed call, got %+v", e)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	if level, err := ParseLogLevel("WARN"); err != nil || level != LevelWarn {
+		t.Errorf("expected warn level, got %v %v", level, err)
+	}
+	if _, err := ParseLogLevel("trace"); err == nil {
+		t.Error("expected unknown level to fail")
+	}
+}
@@ -0,0 +1,413 @@
+package util
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"karhooAPIs.com/karhoopb"
+)
+
+// GeolocationToProto converts a geolocation to its protobuf message
+func GeolocationToProto(g Geolocation) *karhoopb.Geolocation {
+	return &karhoopb.Geolocation{
+		Position:       PositionToProto(g.Position),
+		DisplayAddress: g.DisplayAddress,
+		PlaceId:        g.PlaceID,
+		PoiType:        g.PoiType,
+		Timezone:       g.Timezone,
+	}
+}
+
+// GeolocationFromProto converts a protobuf geolocation, a missing position is left at 0, 0 for Validate to reject
+func GeolocationFromProto(g *karhoopb.Geolocation) Geolocation {
+	return Geolocation{
+		Position:       PositionFromProto(g.GetPosition()),
+		DisplayAddress: g.GetDisplayAddress(),
+		PlaceID:        g.GetPlaceId(),
+		PoiType:        g.GetPoiType(),
+		Timezone:       g.GetTimezone(),
+	}
+}
+
+// PositionToProto converts a position to its protobuf message
+func PositionToProto(p Position) *karhoopb.Position {
+	return &karhoopb.Position{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// PositionFromProto converts a protobuf position
+func PositionFromProto(p *karhoopb.Position) Position {
+	return Position{Latitude: p.GetLatitude(), Longitude: p.GetLongitude()}
+}
+
+// QuotesListToProto converts a quotes list to its protobuf message
+func QuotesListToProto(l *QuotesList) *karhoopb.QuotesList {
+	quotes := make([]*karhoopb.Quote, len(l.Quotes))
+	for i, q := range l.Quotes {
+		quotes[i] = QuoteToProto(q)
+	}
+	return &karhoopb.QuotesList{
+		Id:             l.ID,
+		VehicleClasses: l.Availability.Vehicles.Classes,
+		VehicleTags:    l.Availability.Vehicles.Tags,
+		VehicleTypes:   l.Availability.Vehicles.Types,
+		Quotes:         quotes,
+		Status:         l.Status,
+		Validity:       int32(l.Validity),
+	}
+}
+
+// QuotesListFromProto converts a protobuf quotes list
+func QuotesListFromProto(l *karhoopb.QuotesList) *QuotesList {
+	quotesList := &QuotesList{
+		ID:       l.GetId(),
+		Quotes:   make([]Quote, len(l.GetQuotes())),
+		Status:   l.GetStatus(),
+		Validity: int(l.GetValidity()),
+	}
+	quotesList.Availability.Vehicles.Classes = l.GetVehicleClasses()
+	quotesList.Availability.Vehicles.Tags = l.GetVehicleTags()
+	quotesList.Availability.Vehicles.Types = l.GetVehicleTypes()
+	for i, q := range l.GetQuotes() {
+		quotesList.Quotes[i] = QuoteFromProto(q)
+	}
+	return quotesList
+}
+
+// QuoteToProto converts a quote to its protobuf message
+func QuoteToProto(q Quote) *karhoopb.Quote {
+	return &karhoopb.Quote{
+		Id: q.ID,
+		Price: &karhoopb.Quote_Price{
+			CurrencyCode: q.Price.CurrencyCode,
+			High:         int32(q.Price.High),
+			Low:          int32(q.Price.Low),
+			NetHigh:      int32(q.Price.Net.High),
+			NetLow:       int32(q.Price.Net.Low),
+		},
+		PickUpType: q.PickUpType,
+		QuoteType:  q.QuoteType,
+		Source:     q.Source,
+		Fleet: &karhoopb.Quote_Fleet{
+			Id:                 q.Fleet.ID,
+			Name:               q.Fleet.Name,
+			Description:        q.Fleet.Description,
+			RatingCount:        int32(q.Fleet.Rating.Count),
+			RatingScore:        int32(q.Fleet.Rating.Score),
+			PhoneNumber:        q.Fleet.PhoneNumber,
+			Capabilities:       q.Fleet.Capabilities,
+			LogoUrl:            q.Fleet.LogoURL,
+			TermsConditionsUrl: q.Fleet.TermsConditionsURL,
+		},
+		Vehicle: &karhoopb.Quote_Vehicle{
+			QtaHighMinutes:    int32(q.Vehicle.QTA.HighMinutes),
+			QtaLowMinutes:     int32(q.Vehicle.QTA.LowMinutes),
+			Class:             q.Vehicle.Class,
+			Type:              q.Vehicle.Type,
+			PassengerCapacity: int32(q.Vehicle.PassengerCapacity),
+			LuggageCapacity:   int32(q.Vehicle.LuggageCapacity),
+			Tags:              q.Vehicle.Tags,
+		},
+	}
+}
+
+// QuoteFromProto converts a protobuf quote
+func QuoteFromProto(q *karhoopb.Quote) Quote {
+	var quote Quote
+	quote.ID = q.GetId()
+	quote.Price.CurrencyCode = q.GetPrice().GetCurrencyCode()
+	quote.Price.High = int(q.GetPrice().GetHigh())
+	quote.Price.Low = int(q.GetPrice().GetLow())
+	quote.Price.Net.High = int(q.GetPrice().GetNetHigh())
+	quote.Price.Net.Low = int(q.GetPrice().GetNetLow())
+	quote.PickUpType = q.GetPickUpType()
+	quote.QuoteType = q.GetQuoteType()
+	quote.Source = q.GetSource()
+	quote.Fleet.ID = q.GetFleet().GetId()
+	quote.Fleet.Name = q.GetFleet().GetName()
+	quote.Fleet.Description = q.GetFleet().GetDescription()
+	quote.Fleet.Rating.Count = int(q.GetFleet().GetRatingCount())
+	quote.Fleet.Rating.Score = int(q.GetFleet().GetRatingScore())
+	quote.Fleet.PhoneNumber = q.GetFleet().GetPhoneNumber()
+	quote.Fleet.Capabilities = q.GetFleet().GetCapabilities()
+	quote.Fleet.LogoURL = q.GetFleet().GetLogoUrl()
+	quote.Fleet.TermsConditionsURL = q.GetFleet().GetTermsConditionsUrl()
+	quote.Vehicle.QTA.HighMinutes = int(q.GetVehicle().GetQtaHighMinutes())
+	quote.Vehicle.QTA.LowMinutes = int(q.GetVehicle().GetQtaLowMinutes())
+	quote.Vehicle.Class = q.GetVehicle().GetClass()
+	quote.Vehicle.Type = q.GetVehicle().GetType()
+	quote.Vehicle.PassengerCapacity = int(q.GetVehicle().GetPassengerCapacity())
+	quote.Vehicle.LuggageCapacity = int(q.GetVehicle().GetLuggageCapacity())
+	quote.Vehicle.Tags = q.GetVehicle().GetTags()
+	return quote
+}
+
+// passengersToProto converts the passengers of a booking request or booking
+func passengersToProto(additionalPassengers int, details []Passenger, luggage int) *karhoopb.Passengers {
+	passengers := &karhoopb.Passengers{
+		AdditionalPassengers: int32(additionalPassengers),
+		PassengerDetails:     make([]*karhoopb.Passenger, len(details)),
+		Luggage:              int32(luggage),
+	}
+	for i, p := range details {
+		passengers.PassengerDetails[i] = &karhoopb.Passenger{
+			FirstName:   p.FirstName,
+			LastName:    p.LastName,
+			Email:       p.Email,
+			PhoneNumber: p.PhoneNumber,
+			Locale:      p.Locale,
+		}
+	}
+	return passengers
+}
+
+// passengerDetailsFromProto converts the passengers with contact details
+func passengerDetailsFromProto(p *karhoopb.Passengers) []Passenger {
+	details := make([]Passenger, len(p.GetPassengerDetails()))
+	for i, passenger := range p.GetPassengerDetails() {
+		details[i] = Passenger{
+			FirstName:   passenger.GetFirstName(),
+			LastName:    passenger.GetLastName(),
+			Email:       passenger.GetEmail(),
+			PhoneNumber: passenger.GetPhoneNumber(),
+			Locale:      passenger.GetLocale(),
+		}
+	}
+	return details
+}
+
+// BookingRequestToProto converts a booking request to its protobuf message
+func BookingRequestToProto(r *BookingRequest) *karhoopb.BookingRequest {
+	return &karhoopb.BookingRequest{
+		QuoteId:             r.QuoteID,
+		Passengers:          passengersToProto(r.Passengers.AdditionalPassengers, r.Passengers.PassengerDetails, r.Passengers.Luggage.Total),
+		FlightNumber:        r.FlightNumber,
+		TrainNumber:         r.TrainNumber,
+		Comments:            r.Comments,
+		PartnerTripId:       r.PartnerTripID,
+		CostCenterReference: r.CostCenterReference,
+		Meta:                r.Meta,
+	}
+}
+
+// BookingRequestFromProto converts a protobuf booking request, the result still has to be validated
+func BookingRequestFromProto(r *karhoopb.BookingRequest) *BookingRequest {
+	bookingRequest := &BookingRequest{
+		QuoteID:             r.GetQuoteId(),
+		FlightNumber:        r.GetFlightNumber(),
+		TrainNumber:         r.GetTrainNumber(),
+		Comments:            r.GetComments(),
+		PartnerTripID:       r.GetPartnerTripId(),
+		CostCenterReference: r.GetCostCenterReference(),
+		Meta:                r.GetMeta(),
+	}
+	bookingRequest.Passengers.AdditionalPassengers = int(r.GetPassengers().GetAdditionalPassengers())
+	bookingRequest.Passengers.PassengerDetails = passengerDetailsFromProto(r.GetPassengers())
+	bookingRequest.Passengers.Luggage.Total = int(r.GetPassengers().GetLuggage())
+	return bookingRequest
+}
+
+func breakdownToProto(items []BreakdownItem) []*karhoopb.BreakdownItem {
+	breakdown := make([]*karhoopb.BreakdownItem, len(items))
+	for i, item := range items {
+		breakdown[i] = &karhoopb.BreakdownItem{Value: int32(item.Value), Name: item.Name, Description: item.Description}
+	}
+	return breakdown
+}
+
+func breakdownFromProto(items []*karhoopb.BreakdownItem) []BreakdownItem {
+	breakdown := make([]BreakdownItem, len(items))
+	for i, item := range items {
+		breakdown[i] = BreakdownItem{Value: int(item.GetValue()), Name: item.GetName(), Description: item.GetDescription()}
+	}
+	return breakdown
+}
+
+// timeToProto converts a time, the zero time becomes an unset timestamp
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
+
+// BookingDetailsToProto converts booking details to their protobuf message
+func BookingDetailsToProto(d *BookingDetails) *karhoopb.BookingDetails {
+	return &karhoopb.BookingDetails{
+		Id:                 d.ID,
+		Passengers:         passengersToProto(d.Passengers.AdditionalPassengers, d.Passengers.PassengerDetails, d.Passengers.Luggage.Total),
+		PartnerTravellerId: d.PartnerTravellerID,
+		Status:             string(d.Status),
+		StateDetails:       d.StateDetails,
+		Origin: &karhoopb.Geolocation{
+			Position:       PositionToProto(d.Origin.Position),
+			DisplayAddress: d.Origin.DisplayAddress,
+			PlaceId:        d.Origin.PlaceID,
+			PoiType:        d.Origin.PoiType,
+			Timezone:       d.Origin.Timezone,
+		},
+		Destination: &karhoopb.Geolocation{
+			Position:       PositionToProto(d.Destination.Position),
+			DisplayAddress: d.Destination.DisplayAddress,
+			PlaceId:        d.Destination.PlaceID,
+			PoiType:        d.Destination.PoiType,
+			Timezone:       d.Destination.Timezone,
+		},
+		DateScheduled: timeToProto(d.DateScheduled),
+		Quote: &karhoopb.BookingDetails_Quote{
+			Type:           d.Quote.Type,
+			Total:          int32(d.Quote.Total),
+			Currency:       d.Quote.Currency,
+			Breakdown:      breakdownToProto(d.Quote.Breakdown),
+			VehicleClass:   d.Quote.VehicleClass,
+			QtaHighMinutes: int32(d.Quote.QtaHighMinutes),
+			QtaLowMinutes:  int32(d.Quote.QtaLowMinutes),
+			Source:         d.Quote.Source,
+			HighPrice:      int32(d.Quote.HighPrice),
+			LowPrice:       int32(d.Quote.LowPrice),
+		},
+		Fare: &karhoopb.Fare{
+			Total:           int32(d.Fare.Total),
+			Currency:        d.Fare.Currency,
+			GratuityPercent: int32(d.Fare.GratuityPercent),
+			Breakdown:       breakdownToProto(d.Fare.Breakdown),
+		},
+		ExternalTripId: d.ExternalTripID,
+		DisplayTripId:  d.DisplayTripID,
+		Fleet: &karhoopb.BookingDetails_Fleet{
+			Id:                 d.FleetInfo.FleetID,
+			Name:               d.FleetInfo.Name,
+			Description:        d.FleetInfo.Description,
+			PhoneNumber:        d.FleetInfo.PhoneNumber,
+			Email:              d.FleetInfo.Email,
+			LogoUrl:            d.FleetInfo.LogoURL,
+			TermsConditionsUrl: d.FleetInfo.TermsConditionsURL,
+		},
+		Vehicle: &karhoopb.BookingDetails_Vehicle{
+			VehicleClass: d.Vehicle.VehicleClass,
+			Description:  d.Vehicle.Description,
+			LicensePlate: d.Vehicle.VehicleLicensePlate,
+			Driver: &karhoopb.BookingDetails_Driver{
+				FirstName:     d.Vehicle.Driver.FirstName,
+				LastName:      d.Vehicle.Driver.LastName,
+				PhoneNumber:   d.Vehicle.Driver.PhoneNumber,
+				LicenseNumber: d.Vehicle.Driver.LicenseNumber,
+				PhotoUrl:      d.Vehicle.Driver.PhotoURL,
+			},
+			PassengerCapacity: int32(d.Vehicle.Attributes.PassengerCapacity),
+			LuggageCapacity:   int32(d.Vehicle.Attributes.LuggageCapacity),
+		},
+		PartnerTripId: d.PartnerTripID,
+		Comments:      d.Comments,
+		FlightNumber:  d.FlightNumber,
+		TrainNumber:   d.TrainNumber,
+		DateBooked:    d.DateBooked,
+		MeetingPoint: &karhoopb.BookingDetails_MeetingPoint{
+			Position:     PositionToProto(d.MeetingPoint.Position),
+			Type:         d.MeetingPoint.Type,
+			Instructions: d.MeetingPoint.Instructions,
+			Note:         d.MeetingPoint.Note,
+		},
+		CostCenterReference: d.CostCenterReference,
+		FollowCode:          d.FollowCode,
+	}
+}
+
+// BookingDetailsFromProto converts protobuf booking details, fields the message does not carry are left empty
+func BookingDetailsFromProto(b *karhoopb.BookingDetails) *BookingDetails {
+	d := &BookingDetails{
+		ID:                  b.GetId(),
+		PartnerTravellerID:  b.GetPartnerTravellerId(),
+		Status:              BookingStatus(b.GetStatus()),
+		StateDetails:        b.GetStateDetails(),
+		DateScheduled:       timeFromProto(b.GetDateScheduled()),
+		ExternalTripID:      b.GetExternalTripId(),
+		DisplayTripID:       b.GetDisplayTripId(),
+		PartnerTripID:       b.GetPartnerTripId(),
+		Comments:            b.GetComments(),
+		FlightNumber:        b.GetFlightNumber(),
+		TrainNumber:         b.GetTrainNumber(),
+		DateBooked:          b.GetDateBooked(),
+		CostCenterReference: b.GetCostCenterReference(),
+		FollowCode:          b.GetFollowCode(),
+	}
+	d.Passengers.AdditionalPassengers = int(b.GetPassengers().GetAdditionalPassengers())
+	d.Passengers.PassengerDetails = passengerDetailsFromProto(b.GetPassengers())
+	d.Passengers.Luggage.Total = int(b.GetPassengers().GetLuggage())
+
+	origin := GeolocationFromProto(b.GetOrigin())
+	d.Origin.Position = origin.Position
+	d.Origin.DisplayAddress = origin.DisplayAddress
+	d.Origin.PlaceID = origin.PlaceID
+	d.Origin.PoiType = origin.PoiType
+	d.Origin.Timezone = origin.Timezone
+	destination := GeolocationFromProto(b.GetDestination())
+	d.Destination.Position = destination.Position
+	d.Destination.DisplayAddress = destination.DisplayAddress
+	d.Destination.PlaceID = destination.PlaceID
+	d.Destination.PoiType = destination.PoiType
+	d.Destination.Timezone = destination.Timezone
+
+	d.Quote.Type = b.GetQuote().GetType()
+	d.Quote.Total = int(b.GetQuote().GetTotal())
+	d.Quote.Currency = b.GetQuote().GetCurrency()
+	d.Quote.Breakdown = breakdownFromProto(b.GetQuote().GetBreakdown())
+	d.Quote.VehicleClass = b.GetQuote().GetVehicleClass()
+	d.Quote.QtaHighMinutes = int(b.GetQuote().GetQtaHighMinutes())
+	d.Quote.QtaLowMinutes = int(b.GetQuote().GetQtaLowMinutes())
+	d.Quote.Source = b.GetQuote().GetSource()
+	d.Quote.HighPrice = int(b.GetQuote().GetHighPrice())
+	d.Quote.LowPrice = int(b.GetQuote().GetLowPrice())
+
+	d.Fare = Fare{
+		Total:           int(b.GetFare().GetTotal()),
+		Currency:        b.GetFare().GetCurrency(),
+		GratuityPercent: int(b.GetFare().GetGratuityPercent()),
+		Breakdown:       breakdownFromProto(b.GetFare().GetBreakdown()),
+	}
+
+	d.FleetInfo.FleetID = b.GetFleet().GetId()
+	d.FleetInfo.Name = b.GetFleet().GetName()
+	d.FleetInfo.Description = b.GetFleet().GetDescription()
+	d.FleetInfo.PhoneNumber = b.GetFleet().GetPhoneNumber()
+	d.FleetInfo.Email = b.GetFleet().GetEmail()
+	d.FleetInfo.LogoURL = b.GetFleet().GetLogoUrl()
+	d.FleetInfo.TermsConditionsURL = b.GetFleet().GetTermsConditionsUrl()
+
+	d.Vehicle.VehicleClass = b.GetVehicle().GetVehicleClass()
+	d.Vehicle.Description = b.GetVehicle().GetDescription()
+	d.Vehicle.VehicleLicensePlate = b.GetVehicle().GetLicensePlate()
+	d.Vehicle.Driver.FirstName = b.GetVehicle().GetDriver().GetFirstName()
+	d.Vehicle.Driver.LastName = b.GetVehicle().GetDriver().GetLastName()
+	d.Vehicle.Driver.PhoneNumber = b.GetVehicle().GetDriver().GetPhoneNumber()
+	d.Vehicle.Driver.LicenseNumber = b.GetVehicle().GetDriver().GetLicenseNumber()
+	d.Vehicle.Driver.PhotoURL = b.GetVehicle().GetDriver().GetPhotoUrl()
+	d.Vehicle.Attributes.PassengerCapacity = int(b.GetVehicle().GetPassengerCapacity())
+	d.Vehicle.Attributes.LuggageCapacity = int(b.GetVehicle().GetLuggageCapacity())
+
+	d.MeetingPoint.Position = PositionFromProto(b.GetMeetingPoint().GetPosition())
+	d.MeetingPoint.Type = b.GetMeetingPoint().GetType()
+	d.MeetingPoint.Instructions = b.GetMeetingPoint().GetInstructions()
+	d.MeetingPoint.Note = b.GetMeetingPoint().GetNote()
+	return d
+}
+
+// BookingEventToProto converts a booking event, events carrying an error are not sent as messages
+func BookingEventToProto(e BookingEvent) *karhoopb.BookingEvent {
+	changes := make([]*karhoopb.FieldChange, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = &karhoopb.FieldChange{Field: c.Field, Old: c.Old, New: c.New}
+	}
+	event := &karhoopb.BookingEvent{Changes: changes}
+	if e.Details != nil {
+		event.Details = BookingDetailsToProto(e.Details)
+	}
+	return event
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"karhooAPIs.com/karhoopb"
+)
+
+func decodeExampleResponse(t *testing.T, name string, v interface{}) {
+	f, err := os.Open("../example_responses/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	err = json.NewDecoder(f).Decode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuotesListProto(t *testing.T) {
+	var quotesList QuotesList
+	decodeExampleResponse(t, "quoteList.json", &quotesList)
+
+	message := QuotesListToProto(&quotesList)
+	if len(message.Quotes) != len(quotesList.Quotes) || message.Quotes[0].Price.High != int32(quotesList.Quotes[0].Price.High) {
+		t.Errorf("expected quotes to be converted, got %v", message.Quotes)
+	}
+	if got := QuotesListFromProto(message); !reflect.DeepEqual(got, &quotesList) {
+		t.Errorf("expected round trip to keep the quotes list\n got %+v\nwant %+v", got, &quotesList)
+	}
+}
+
+func TestBookingRequestProto(t *testing.T) {
+	bookingRequest, err := NewBookingRequestBuilderForQuoteID("quote-1").
+		Passenger("John", "Smith", "+4412345678").
+		AdditionalPassengers(2).
+		Luggage(3).
+		FlightNumber("lh 400").
+		CostCenter("CC-7").
+		Meta("employee", "42").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := BookingRequestToProto(bookingRequest)
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &karhoopb.BookingRequest{}
+	err = proto.Unmarshal(data, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := BookingRequestFromProto(decoded); !reflect.DeepEqual(got, bookingRequest) {
+		t.Errorf("expected round trip to keep the booking request\n got %+v\nwant %+v", got, bookingRequest)
+	}
+}
+
+func TestBookingDetailsProto(t *testing.T) {
+	var bookingDetails BookingDetails
+	decodeExampleResponse(t, "bookDetails.json", &bookingDetails)
+
+	message := BookingDetailsToProto(&bookingDetails)
+	got := BookingDetailsFromProto(message)
+	if !proto.Equal(BookingDetailsToProto(got), message) {
+		t.Errorf("expected round trip to keep all fields of the message")
+	}
+	if got.Status != bookingDetails.Status || got.Origin.Position != bookingDetails.Origin.Position ||
+		got.Vehicle.Driver.FirstName != bookingDetails.Vehicle.Driver.FirstName || got.Fare.Total != bookingDetails.Fare.Total ||
+		!reflect.DeepEqual(got.Passengers.PassengerDetails, bookingDetails.Passengers.PassengerDetails) {
+		t.Errorf("expected booking details to survive the round trip, got %+v", got)
+	}
+	if !got.DateScheduled.Equal(bookingDetails.DateScheduled) {
+		t.Errorf("expected date scheduled %s, got %s", bookingDetails.DateScheduled, got.DateScheduled)
+	}
+
+	if message := BookingDetailsToProto(&BookingDetails{}); message.DateScheduled != nil {
+		t.Error("expected zero time to be left unset")
+	}
+	if got := BookingDetailsFromProto(&karhoopb.BookingDetails{}); !got.DateScheduled.IsZero() {
+		t.Error("expected unset timestamp to become the zero time")
+	}
+}
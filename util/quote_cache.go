@@ -0,0 +1,208 @@
+package util
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// QuoteCacheBackend stores cached quotes lists. The in memory backend serves a single process, implement it on top of
+// a shared store to share quotes between processes
+type QuoteCacheBackend interface {
+	Get(key string) (quotesList *QuotesList, expiresAt time.Time, ok bool, err error)
+	Set(key string, quotesList *QuotesList, expiresAt time.Time) error
+	Delete(key string) error
+	// SetKey records the key of the entry holding a quotes list, so that every process sharing the backend can
+	// invalidate the entry by quote
+	SetKey(quotesListID, key string, expiresAt time.Time) error
+	// Key returns the key of the entry holding a quotes list
+	Key(quotesListID string) (key string, ok bool, err error)
+	DeleteKey(quotesListID string) error
+}
+
+type cachedQuotesList struct {
+	quotesList *QuotesList
+	expiresAt  time.Time
+}
+
+type quoteCacheIndex struct {
+	key       string
+	expiresAt time.Time
+}
+
+// memoryQuoteCacheBackend keeps quotes lists in process memory
+type memoryQuoteCacheBackend struct {
+	mutex   sync.Mutex
+	entries map[string]cachedQuotesList
+	keys    map[string]quoteCacheIndex
+}
+
+// NewMemoryQuoteCacheBackend creates a backend caching quotes lists in process memory
+func NewMemoryQuoteCacheBackend() QuoteCacheBackend {
+	return &memoryQuoteCacheBackend{entries: map[string]cachedQuotesList{}, keys: map[string]quoteCacheIndex{}}
+}
+
+func (m *memoryQuoteCacheBackend) Get(key string) (*QuotesList, time.Time, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	e, ok := m.entries[key]
+	return e.quotesList, e.expiresAt, ok, nil
+}
+
+func (m *memoryQuoteCacheBackend) Set(key string, quotesList *QuotesList, expiresAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	// drop expired entries so that the cache does not grow forever
+	now := time.Now()
+	for k, e := range m.entries {
+		if !e.expiresAt.After(now) {
+			delete(m.entries, k)
+		}
+	}
+	m.entries[key] = cachedQuotesList{quotesList: quotesList, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *memoryQuoteCacheBackend) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *memoryQuoteCacheBackend) SetKey(quotesListID, key string, expiresAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	now := time.Now()
+	for id, index := range m.keys {
+		if !index.expiresAt.After(now) {
+			delete(m.keys, id)
+		}
+	}
+	m.keys[quotesListID] = quoteCacheIndex{key: key, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *memoryQuoteCacheBackend) Key(quotesListID string) (string, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	index, ok := m.keys[quotesListID]
+	return index.key, ok, nil
+}
+
+func (m *memoryQuoteCacheBackend) DeleteKey(quotesListID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.keys, quotesListID)
+	return nil
+}
+
+// QuoteCache serves quotes lists of the same route and pickup time until their validity expires
+type QuoteCache struct {
+	backend   QuoteCacheBackend
+	precision int
+}
+
+// NewQuoteCache creates a quote cache, coordinates are rounded to precision decimal places so that the same place
+// typed slightly differently hits the same entry
+func NewQuoteCache(backend QuoteCacheBackend, precision int) *QuoteCache {
+	return &QuoteCache{backend: backend, precision: precision}
+}
+
+// QuoteCacheKey normalized key of a route and pickup time, pickup times are truncated to the minute and an empty
+// pickup time means an immediate pickup
+func QuoteCacheKey(origin, destination Geolocation, pickupTime string, precision int) string {
+	pickup := "ASAP"
+	if pickupTime != "" {
+		pickup = strings.TrimSpace(pickupTime)
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04"} {
+			if t, err := time.Parse(layout, pickup); err == nil {
+				pickup = t.Format("2006-01-02T15:04")
+				break
+			}
+		}
+	}
+	return formatCoordinate(origin.Position.Latitude, precision) + "," + formatCoordinate(origin.Position.Longitude, precision) +
+		"|" + formatCoordinate(destination.Position.Latitude, precision) + "," + formatCoordinate(destination.Position.Longitude, precision) +
+		"|" + pickup
+}
+
+// Get returns a copy of the cached quotes list of a route if it is still valid, callers may change it. The validity
+// of the copy is the time left until the cached list expires
+func (c *QuoteCache) Get(origin, destination Geolocation, pickupTime string, now time.Time) (*QuotesList, bool) {
+	key := QuoteCacheKey(origin, destination, pickupTime, c.precision)
+	quotesList, expiresAt, ok, err := c.backend.Get(key)
+	if err != nil || !ok {
+		return nil, false
+	}
+	validity := int(expiresAt.Sub(now) / time.Second)
+	if validity <= 0 {
+		return nil, false
+	}
+	l := copyQuotesList(quotesList)
+	l.Validity = validity
+	return l, true
+}
+
+// copyQuotesList deep copies a quotes list so that cached lists are never shared with callers
+func copyQuotesList(l *QuotesList) *QuotesList {
+	c := *l
+	c.Availability.Vehicles.Classes = copyStrings(l.Availability.Vehicles.Classes)
+	c.Availability.Vehicles.Tags = copyStrings(l.Availability.Vehicles.Tags)
+	c.Availability.Vehicles.Types = copyStrings(l.Availability.Vehicles.Types)
+	if l.Quotes != nil {
+		c.Quotes = make([]Quote, len(l.Quotes))
+		for i, q := range l.Quotes {
+			q.Fleet.Capabilities = copyStrings(q.Fleet.Capabilities)
+			q.Vehicle.Tags = copyStrings(q.Vehicle.Tags)
+			c.Quotes[i] = q
+		}
+	}
+	return &c
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+// Set caches a copy of a completed quotes list retrieved at retrievedAt until its validity expires
+func (c *QuoteCache) Set(origin, destination Geolocation, pickupTime string, quotesList *QuotesList, retrievedAt time.Time) error {
+	if quotesList.Validity <= 0 || len(quotesList.Quotes) == 0 {
+		return nil
+	}
+	key := QuoteCacheKey(origin, destination, pickupTime, c.precision)
+	expiresAt := QuoteExpiration(retrievedAt, quotesList.Validity)
+	if err := c.backend.Set(key, copyQuotesList(quotesList), expiresAt); err != nil {
+		return err
+	}
+	return c.backend.SetKey(quotesList.ID, key, expiresAt)
+}
+
+// InvalidateQuote removes the quotes list containing a quote that was booked or rejected as expired, so that the
+// next lookup of the route gets fresh quotes
+func (c *QuoteCache) InvalidateQuote(quoteID string) error {
+	// quote IDs are prefixed with the ID of their quotes list, try every prefix ending before a colon
+	candidates := []string{quoteID}
+	for i, r := range quoteID {
+		if r == ':' {
+			candidates = append(candidates, quoteID[:i])
+		}
+	}
+	for _, quotesListID := range candidates {
+		key, ok, err := c.backend.Key(quotesListID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := c.backend.Delete(key); err != nil {
+			return err
+		}
+		return c.backend.DeleteKey(quotesListID)
+	}
+	return nil
+}
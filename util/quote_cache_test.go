@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuoteCache(t *testing.T) {
+	c := NewQuoteCache(NewMemoryQuoteCacheBackend(), 4)
+	origin := Geolocation{Position: Position{Latitude: 50.037933, Longitude: 8.562152}}
+	destination := Geolocation{Position: Position{Latitude: 50.107145, Longitude: 8.663789}}
+	quotesList := &QuotesList{ID: "list", Validity: 300, Quotes: []Quote{{ID: "list:quote"}}}
+	now := time.Now()
+
+	err := c.Set(origin, destination, "2021-01-08T10:30:00", quotesList, now)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	nearby := Geolocation{Position: Position{Latitude: 50.037941, Longitude: 8.562161}}
+	cached, ok := c.Get(nearby, destination, "2021-01-08T10:30", now)
+	if !ok {
+		t.Fatal("expected normalized route and pickup time to hit the cache")
+	}
+	// callers get their own copy of the cached quotes list
+	cached.Quotes[0].ID = "changed"
+	quotesList.Quotes[0].Vehicle.Tags = []string{"electric"}
+	if cached, _ := c.Get(origin, destination, "2021-01-08T10:30", now); cached.Quotes[0].ID != "list:quote" ||
+		len(cached.Quotes[0].Vehicle.Tags) != 0 {
+		t.Errorf("expected the cached quotes list to be unchanged, got %+v", cached.Quotes[0])
+	}
+	// the validity of a cached list is the time it has left
+	if cached, _ := c.Get(origin, destination, "2021-01-08T10:30", now.Add(time.Minute)); cached == nil ||
+		cached.Validity != 240 {
+		t.Errorf("expected 240 seconds of validity left, got %+v", cached)
+	}
+	if _, ok := c.Get(origin, destination, "", now); ok {
+		t.Error("expected immediate pickup not to hit the scheduled pickup entry")
+	}
+	if _, ok := c.Get(origin, destination, "2021-01-08T10:30", now.Add(time.Minute*5)); ok {
+		t.Error("expected entry to expire with the quotes list validity")
+	}
+
+	c.Set(origin, destination, "", quotesList, now)
+	c.InvalidateQuote("list:quote")
+	if _, ok := c.Get(origin, destination, "", now); ok {
+		t.Error("expected booked quote to invalidate its quotes list")
+	}
+
+	// caches sharing a backend invalidate each other's entries
+	backend := NewMemoryQuoteCacheBackend()
+	c = NewQuoteCache(backend, 4)
+	c.Set(origin, destination, "", quotesList, now)
+	NewQuoteCache(backend, 4).InvalidateQuote("list:quote")
+	if _, ok := c.Get(origin, destination, "", now); ok {
+		t.Error("expected a quote booked through another cache to invalidate the shared entry")
+	}
+}
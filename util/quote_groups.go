@@ -0,0 +1,39 @@
+package util
+
+import "sort"
+
+// VehicleClassQuotes quotes of a vehicle class, cheapest first
+type VehicleClassQuotes struct {
+	Class  string
+	Quotes []Quote
+}
+
+// Cheapest returns the quote with the lowest price of the class
+func (g VehicleClassQuotes) Cheapest() Quote {
+	return g.Quotes[0]
+}
+
+// GroupQuotesByVehicleClass groups quotes by vehicle class, quotes are sorted by lowest price within a class and
+// classes by their cheapest quote
+func GroupQuotesByVehicleClass(quotes []Quote) []VehicleClassQuotes {
+	index := map[string]int{}
+	groups := []VehicleClassQuotes{}
+	for _, quote := range quotes {
+		i, ok := index[quote.Vehicle.Class]
+		if !ok {
+			i = len(groups)
+			index[quote.Vehicle.Class] = i
+			groups = append(groups, VehicleClassQuotes{Class: quote.Vehicle.Class})
+		}
+		groups[i].Quotes = append(groups[i].Quotes, quote)
+	}
+	for _, g := range groups {
+		sort.SliceStable(g.Quotes, func(i, j int) bool {
+			return g.Quotes[i].Price.Low < g.Quotes[j].Price.Low
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Cheapest().Price.Low < groups[j].Cheapest().Price.Low
+	})
+	return groups
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestGroupQuotesByVehicleClass(t *testing.T) {
+	newQuote := func(id, class string, low int) Quote {
+		var q Quote
+		q.ID = id
+		q.Vehicle.Class = class
+		q.Price.Low = low
+		return q
+	}
+	groups := GroupQuotesByVehicleClass([]Quote{
+		newQuote("exec", "Exec", 5000),
+		newQuote("saloon-expensive", "Saloon", 3000),
+		newQuote("saloon-cheap", "Saloon", 2500),
+	})
+	if len(groups) != 2 {
+		t.Errorf("expected 2 vehicle classes, got %d", len(groups))
+		return
+	}
+	if groups[0].Class != "Saloon" || groups[0].Cheapest().ID != "saloon-cheap" {
+		t.Errorf("expected cheapest saloon first, got %s %s", groups[0].Class, groups[0].Cheapest().ID)
+	}
+}
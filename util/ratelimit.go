@@ -0,0 +1,162 @@
+package util
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit token bucket refilled with Rate tokens per second holding at most Burst tokens
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitBucket a token bucket a call takes a token from
+type RateLimitBucket struct {
+	Key   string
+	Limit RateLimit
+}
+
+// RateLimitBackend stores the token buckets. The in memory backend limits a single process, implement it on top of a
+// shared store such as redis to share the budget across processes
+type RateLimitBackend interface {
+	// Take takes a token from every bucket at once. If a bucket has none left no token is taken from any of them, and
+	// it returns how long to wait before trying again
+	Take(buckets []RateLimitBucket, now time.Time) (wait time.Duration, err error)
+}
+
+// tokenBucket state of a single bucket of the in memory backend
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// memoryRateLimitBackend keeps the buckets in process memory
+type memoryRateLimitBackend struct {
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewMemoryRateLimitBackend creates a backend limiting the calls of this process only
+func NewMemoryRateLimitBackend() RateLimitBackend {
+	return &memoryRateLimitBackend{buckets: map[string]*tokenBucket{}}
+}
+
+func (m *memoryRateLimitBackend) Take(buckets []RateLimitBucket, now time.Time) (time.Duration, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var wait time.Duration
+	for _, bucket := range buckets {
+		b, ok := m.buckets[bucket.Key]
+		if !ok {
+			b = &tokenBucket{tokens: float64(bucket.Limit.Burst), last: now}
+			m.buckets[bucket.Key] = b
+		}
+		if now.After(b.last) {
+			b.tokens = math.Min(float64(bucket.Limit.Burst), b.tokens+now.Sub(b.last).Seconds()*bucket.Limit.Rate)
+			b.last = now
+		}
+		if b.tokens < 1 {
+			if w := time.Duration((1 - b.tokens) / bucket.Limit.Rate * float64(time.Second)); w > wait {
+				wait = w
+			}
+		}
+	}
+	if wait > 0 {
+		return wait, nil
+	}
+	for _, bucket := range buckets {
+		m.buckets[bucket.Key].tokens--
+	}
+	return 0, nil
+}
+
+// RateLimiter limits calls globally and per endpoint, a call has to get a token from both buckets
+type RateLimiter struct {
+	backend   RateLimitBackend
+	global    *RateLimit
+	mutex     sync.Mutex
+	endpoints map[string]RateLimit
+}
+
+// NewRateLimiter creates a rate limiter, global is optional and limits all endpoints together
+func NewRateLimiter(backend RateLimitBackend, global *RateLimit) *RateLimiter {
+	return &RateLimiter{backend: backend, global: global, endpoints: map[string]RateLimit{}}
+}
+
+// SetEndpointLimit limits calls to an endpoint, the endpoint is a key as returned by EndpointKey, e.g.
+// "POST /v1/bookings/:id/cancel"
+func (l *RateLimiter) SetEndpointLimit(endpoint string, limit RateLimit) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.endpoints[endpoint] = limit
+}
+
+// Wait blocks until a call to the endpoint is allowed or ctx is done. The endpoint and the global token are taken
+// together, so that waiting for one does not hold the other
+func (l *RateLimiter) Wait(ctx context.Context, endpoint string) error {
+	buckets := []RateLimitBucket{}
+	l.mutex.Lock()
+	if limit, limited := l.endpoints[endpoint]; limited {
+		buckets = appendRateLimitBucket(buckets, "endpoint:"+endpoint, limit)
+	}
+	l.mutex.Unlock()
+	if l.global != nil {
+		buckets = appendRateLimitBucket(buckets, "global", *l.global)
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+	for {
+		wait, err := l.backend.Take(buckets, time.Now())
+		if err != nil {
+			return err
+		}
+		if wait <= 0 {
+			return nil
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// appendRateLimitBucket adds a bucket unless its limit is unlimited, a burst below 1 is raised to 1
+func appendRateLimitBucket(buckets []RateLimitBucket, key string, limit RateLimit) []RateLimitBucket {
+	if limit.Rate <= 0 {
+		return buckets
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return append(buckets, RateLimitBucket{Key: key, Limit: limit})
+}
+
+// versionSegment api version path segments are kept when building endpoint keys
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// EndpointKey identifies the endpoint of a request independently of booking IDs, quote IDs etc. in its path,
+// path segments containing digits are replaced by ":id" and the query is dropped
+func EndpointKey(method, rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if !versionSegment.MatchString(segment) && strings.ContainsAny(segment, "0123456789") {
+			segments[i] = ":id"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/")
+}
+
+// Limiter rate limiter applied to every PostRequest and GetRequest, nil means calls are not limited
+var Limiter *RateLimiter
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitBackendTake(t *testing.T) {
+	b := NewMemoryRateLimitBackend()
+	buckets := []RateLimitBucket{{Key: "key", Limit: RateLimit{Rate: 2, Burst: 2}}}
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if wait, _ := b.Take(buckets, now); wait != 0 {
+			t.Errorf("expected burst token %d to be available, got wait %v", i, wait)
+		}
+	}
+	if wait, _ := b.Take(buckets, now); wait != time.Millisecond*500 {
+		t.Errorf("expected to wait 500ms for the next token, got %v", wait)
+	}
+	if wait, _ := b.Take(buckets, now.Add(time.Millisecond*500)); wait != 0 {
+		t.Errorf("expected token to be refilled, got wait %v", wait)
+	}
+}
+
+func TestMemoryRateLimitBackendTakesAllOrNothing(t *testing.T) {
+	b := NewMemoryRateLimitBackend()
+	endpoint := RateLimitBucket{Key: "endpoint:POST /v1/bookings", Limit: RateLimit{Rate: 1, Burst: 1}}
+	global := RateLimitBucket{Key: "global", Limit: RateLimit{Rate: 1, Burst: 1}}
+	now := time.Now()
+	if wait, _ := b.Take([]RateLimitBucket{global}, now); wait != 0 {
+		t.Fatalf("expected the global token to be available, got wait %v", wait)
+	}
+	// the global bucket is empty, the endpoint token must stay in its bucket while waiting
+	if wait, _ := b.Take([]RateLimitBucket{endpoint, global}, now); wait != time.Second {
+		t.Errorf("expected to wait a second for the global token, got %v", wait)
+	}
+	if wait, _ := b.Take([]RateLimitBucket{endpoint}, now); wait != 0 {
+		t.Errorf("expected the endpoint token not to be taken by the call waiting for the global one, got wait %v", wait)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(NewMemoryRateLimitBackend(), &RateLimit{Rate: 1000, Burst: 1})
+	l.SetEndpointLimit("POST /v1/bookings", RateLimit{Rate: 0})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background(), "POST /v1/bookings"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if time.Since(start) < time.Millisecond {
+		t.Error("expected the global limit to space out calls to an unlimited endpoint")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l = NewRateLimiter(NewMemoryRateLimitBackend(), &RateLimit{Rate: 0.001, Burst: 1})
+	l.Wait(ctx, "GET /v1/bookings/:id")
+	if err := l.Wait(ctx, "GET /v1/bookings/:id"); err != context.Canceled {
+		t.Errorf("expected waiting to stop with the context, got %v", err)
+	}
+}
+
+func TestEndpointKey(t *testing.T) {
+	key := EndpointKey("POST", "https://rest.sandbox.karhoo.com/v1/bookings/b6a5f9dc-9066-4252-9013-be85dfa563bc/cancel/")
+	if key != "POST /v1/bookings/:id/cancel" {
+		t.Errorf("unexpected endpoint key %s", key)
+	}
+	key = EndpointKey("GET", "https://rest.sandbox.karhoo.com/v2/quotes/coverage?latitude=50.037933&longitude=8.562152")
+	if key != "GET /v2/quotes/coverage" {
+		t.Errorf("unexpected endpoint key %s", key)
+	}
+}
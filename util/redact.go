@@ -0,0 +1,244 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Sensitivity how a field is masked when logged, set on struct fields with a redact tag, e.g. `redact:"personal"`
+type Sensitivity int
+
+const (
+	// NotSensitive logged as is
+	NotSensitive Sensitivity = iota
+	// Personal personal data of passengers, drivers and agents, e.g. names, email addresses and phone numbers
+	Personal
+	// Secret credentials and tokens, never logged at any level
+	Secret
+)
+
+// RedactionLevel how much of personal data is logged
+type RedactionLevel int
+
+const (
+	// RedactProduction masks personal data and secrets completely, the default
+	RedactProduction RedactionLevel = iota
+	// RedactDebug keeps the first and last characters of personal data to tell records apart, secrets are still masked
+	RedactDebug
+)
+
+// redactedValue replaces masked values
+const redactedValue = "[REDACTED]"
+
+// LogRedaction level PrintInterface and the other logging of the client redacts with
+var LogRedaction = RedactProduction
+
+// ParseRedactionLevel parses "production" or "debug"
+func ParseRedactionLevel(s string) (RedactionLevel, error) {
+	switch strings.ToLower(s) {
+	case "production", "":
+		return RedactProduction, nil
+	case "debug":
+		return RedactDebug, nil
+	}
+	return RedactProduction, fmt.Errorf("unknown redaction level %q, use production or debug", s)
+}
+
+var (
+	sensitiveFieldsMutex sync.RWMutex
+	// sensitiveFields json keys masked where no struct describes the data, e.g. in maps and raw payloads
+	sensitiveFields = map[string]Sensitivity{
+		"first_name":     Personal,
+		"last_name":      Personal,
+		"email":          Personal,
+		"phone_number":   Personal,
+		"license_number": Personal,
+		"username":       Secret,
+		"password":       Secret,
+		"access_token":   Secret,
+		"refresh_token":  Secret,
+		"shared_secret":  Secret,
+		"secret":         Secret,
+	}
+)
+
+// RegisterSensitiveField marks a json key as sensitive in data no struct describes, e.g. maps and raw payloads. Struct
+// fields are marked with a redact tag instead
+func RegisterSensitiveField(key string, s Sensitivity) {
+	sensitiveFieldsMutex.Lock()
+	defer sensitiveFieldsMutex.Unlock()
+	sensitiveFields[strings.ToLower(key)] = s
+}
+
+func registeredSensitivity(key string) Sensitivity {
+	sensitiveFieldsMutex.RLock()
+	defer sensitiveFieldsMutex.RUnlock()
+	return sensitiveFields[strings.ToLower(key)]
+}
+
+// Redact marshals v to json with its sensitive fields masked. Struct fields are masked by their redact tag, maps and
+// interfaces by the keys of RegisterSensitiveField
+func Redact(v interface{}, level RedactionLevel) (json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return redactJSON(b, reflect.TypeOf(v), level)
+}
+
+// RedactJSON masks the registered sensitive keys of a json document, the document is returned unchanged if it is not
+// valid json
+func RedactJSON(data []byte, level RedactionLevel) json.RawMessage {
+	redacted, err := redactJSON(data, nil, level)
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+func redactJSON(data []byte, t reflect.Type, level RedactionLevel) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers as written instead of converting them to float64
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactValue(v, t, level))
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// redactValue masks the sensitive fields of a decoded json value, t is the go type it was marshalled from, nil if
+// unknown
+func redactValue(v interface{}, t reflect.Type, level RedactionLevel) interface{} {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// custom marshalers decide their json themselves, fall back to the registered keys
+	if t != nil && (t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType)) {
+		t = nil
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		var fields map[string]structField
+		var elem reflect.Type
+		if t != nil && t.Kind() == reflect.Struct {
+			fields = jsonFields(t)
+		} else if t != nil && t.Kind() == reflect.Map {
+			elem = t.Elem()
+		}
+		for key, value := range v {
+			var s Sensitivity
+			fieldType := elem
+			if field, ok := fields[key]; ok {
+				s, fieldType = field.sensitivity, field.typ
+			} else {
+				s = registeredSensitivity(key)
+			}
+			if s != NotSensitive {
+				v[key] = mask(value, s, level)
+				continue
+			}
+			v[key] = redactValue(value, fieldType, level)
+		}
+	case []interface{}:
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		for i := range v {
+			v[i] = redactValue(v[i], elem, level)
+		}
+	}
+	return v
+}
+
+// structField json field of a struct
+type structField struct {
+	typ         reflect.Type
+	sensitivity Sensitivity
+}
+
+// jsonFields the fields of a struct by json key, including the promoted fields of embedded structs
+func jsonFields(t reflect.Type) map[string]structField {
+	fields := map[string]structField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, field := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = field
+					}
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		var s Sensitivity
+		switch f.Tag.Get("redact") {
+		case "personal":
+			s = Personal
+		case "secret":
+			s = Secret
+		}
+		fields[name] = structField{typ: f.Type, sensitivity: s}
+	}
+	return fields
+}
+
+// hasSensitiveFields checks if values of the type can hold struct fields with a redact tag, seen stops the recursion
+// on self referencing types
+func hasSensitiveFields(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return hasSensitiveFields(t.Elem(), seen)
+	case reflect.Struct:
+		for _, field := range jsonFields(t) {
+			if field.sensitivity != NotSensitive || hasSensitiveFields(field.typ, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mask masks a sensitive value, empty values reveal nothing and stay empty
+func mask(v interface{}, s Sensitivity, level RedactionLevel) interface{} {
+	str, ok := v.(string)
+	if v == nil || ok && str == "" {
+		return v
+	}
+	if !ok || s == Secret || level != RedactDebug {
+		return redactedValue
+	}
+	n := utf8.RuneCountInString(str)
+	if n <= 4 {
+		return strings.Repeat("*", n)
+	}
+	runes := []rune(str)
+	return string(runes[0]) + strings.Repeat("*", n-3) + string(runes[n-2:])
+}
@@ -0,0 +1,113 @@
+package util
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func redactTestBooking() *BookingDetails {
+	d := &BookingDetails{ID: "booking-1"}
+	d.Passengers.PassengerDetails = []Passenger{{FirstName: "John", LastName: "Smith", Email: "john.smith@example.com",
+		PhoneNumber: "+15005550006", Locale: "en-GB"}}
+	d.FleetInfo.Name = "Global PHV"
+	d.FleetInfo.PhoneNumber = "+441234567890"
+	d.Vehicle.Driver.FirstName = "Michael"
+	d.Vehicle.Driver.LicenseNumber = "ZXZ151YTY"
+	return d
+}
+
+func decodeRedacted(t *testing.T, redacted json.RawMessage) *BookingDetails {
+	var d BookingDetails
+	if err := json.Unmarshal(redacted, &d); err != nil {
+		t.Fatal(err)
+	}
+	return &d
+}
+
+func TestRedactProduction(t *testing.T) {
+	redacted, err := Redact(redactTestBooking(), RedactProduction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := decodeRedacted(t, redacted)
+	p := d.Passengers.PassengerDetails[0]
+	for _, v := range []string{p.FirstName, p.LastName, p.Email, p.PhoneNumber, d.Vehicle.Driver.FirstName, d.Vehicle.Driver.LicenseNumber} {
+		if v != redactedValue {
+			t.Errorf("expected personal data to be masked, got %q", v)
+		}
+	}
+	if p.Locale != "en-GB" || d.ID != "booking-1" {
+		t.Errorf("expected other fields to be kept, got %+v", d)
+	}
+	// the fleet's phone number is not personal data even though the json key is the passenger's
+	if d.FleetInfo.PhoneNumber != "+441234567890" {
+		t.Errorf("expected the fleet phone number to be kept, got %q", d.FleetInfo.PhoneNumber)
+	}
+	// empty values reveal nothing and stay empty
+	if d.Vehicle.Driver.LastName != "" {
+		t.Errorf("expected empty driver last name to stay empty, got %q", d.Vehicle.Driver.LastName)
+	}
+}
+
+func TestRedactDebug(t *testing.T) {
+	redacted, err := Redact(&AuthInfo{AccessToken: "eyJhbGciOi", RefreshToken: "eyJhbGciOi"}, RedactDebug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var a AuthInfo
+	if err := json.Unmarshal(redacted, &a); err != nil {
+		t.Fatal(err)
+	}
+	if a.AccessToken != redactedValue || a.RefreshToken != redactedValue {
+		t.Errorf("expected tokens to be masked at debug level, got %+v", a)
+	}
+
+	redacted, err = Redact(redactTestBooking(), RedactDebug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := decodeRedacted(t, redacted).Passengers.PassengerDetails[0]
+	if p.Email != "j*******************om" || p.FirstName != "****" {
+		t.Errorf("expected personal data to be partly masked, got %+v", p)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	payload := []byte(`{"username":"john","password":"secret","quote":{"price":17.11,"email":"john.smith@example.com"}}`)
+	var redacted struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Quote    struct {
+			Price json.Number `json:"price"`
+			Email string      `json:"email"`
+		} `json:"quote"`
+	}
+	if err := json.Unmarshal(RedactJSON(payload, RedactProduction), &redacted); err != nil {
+		t.Fatal(err)
+	}
+	if redacted.Username != redactedValue || redacted.Password != redactedValue || redacted.Quote.Email != redactedValue {
+		t.Errorf("expected registered keys to be masked, got %+v", redacted)
+	}
+	if redacted.Quote.Price != "17.11" {
+		t.Errorf("expected numbers to be kept as written, got %s", redacted.Quote.Price)
+	}
+
+	RegisterSensitiveField("price", Personal)
+	defer RegisterSensitiveField("price", NotSensitive)
+	if redacted := string(RedactJSON(payload, RedactProduction)); !strings.Contains(redacted, `"price":"[REDACTED]"`) {
+		t.Errorf("expected the registered price to be masked, got %s", redacted)
+	}
+}
+
+func TestParseRedactionLevel(t *testing.T) {
+	if level, err := ParseRedactionLevel("Debug"); err != nil || level != RedactDebug {
+		t.Errorf("expected debug level, got %v %v", level, err)
+	}
+	if level, err := ParseRedactionLevel(""); err != nil || level != RedactProduction {
+		t.Errorf("expected production level by default, got %v %v", level, err)
+	}
+	if _, err := ParseRedactionLevel("verbose"); err == nil {
+		t.Error("expected unknown level to fail")
+	}
+}
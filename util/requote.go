@@ -0,0 +1,71 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoEquivalentQuote re-quoting found no quote of the same fleet and vehicle class within the price tolerance
+var ErrNoEquivalentQuote = errors.New("no equivalent quote available")
+
+// FindEquivalentQuote finds the quote of the same fleet and vehicle class as original whose price does not exceed the
+// original high price by more than tolerance, the cheapest one if there are several
+func FindEquivalentQuote(original Quote, quotes []Quote, tolerance FareTolerance) (*Quote, error) {
+	var equivalent *Quote
+	tooExpensive := false
+	for i, q := range quotes {
+		if q.Fleet.ID != original.Fleet.ID || q.Vehicle.Class != original.Vehicle.Class ||
+			q.Price.CurrencyCode != original.Price.CurrencyCode {
+			continue
+		}
+		if q.Price.High > tolerance.Allowed(original.Price.High) {
+			tooExpensive = true
+			continue
+		}
+		if equivalent == nil || q.Price.High < equivalent.Price.High {
+			equivalent = &quotes[i]
+		}
+	}
+	if equivalent == nil {
+		if tooExpensive {
+			return nil, fmt.Errorf("%w: fleet %s now charges more than the tolerated %d %s for %s",
+				ErrNoEquivalentQuote, original.Fleet.Name, tolerance.Allowed(original.Price.High), original.Price.CurrencyCode, original.Vehicle.Class)
+		}
+		return nil, fmt.Errorf("%w: fleet %s no longer offers %s", ErrNoEquivalentQuote, original.Fleet.Name, original.Vehicle.Class)
+	}
+	return equivalent, nil
+}
+
+// PriceChange price difference between an expired quote and the quote booked instead
+type PriceChange struct {
+	OldQuoteID   string `json:"old_quote_id"`
+	NewQuoteID   string `json:"new_quote_id"`
+	CurrencyCode string `json:"currency_code"`
+	OldLow       int    `json:"old_low"`
+	OldHigh      int    `json:"old_high"`
+	NewLow       int    `json:"new_low"`
+	NewHigh      int    `json:"new_high"`
+}
+
+// NewPriceChange compares the prices of two quotes
+func NewPriceChange(old, new Quote) *PriceChange {
+	return &PriceChange{
+		OldQuoteID:   old.ID,
+		NewQuoteID:   new.ID,
+		CurrencyCode: new.Price.CurrencyCode,
+		OldLow:       old.Price.Low,
+		OldHigh:      old.Price.High,
+		NewLow:       new.Price.Low,
+		NewHigh:      new.Price.High,
+	}
+}
+
+// Difference how much more the new quote costs at most, negative if it is cheaper
+func (c *PriceChange) Difference() int {
+	return c.NewHigh - c.OldHigh
+}
+
+// Changed checks if the price range differs
+func (c *PriceChange) Changed() bool {
+	return c.OldLow != c.NewLow || c.OldHigh != c.NewHigh
+}
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindEquivalentQuote(t *testing.T) {
+	newQuote := func(id, fleetID, class string, high int) Quote {
+		var q Quote
+		q.ID = id
+		q.Fleet.ID = fleetID
+		q.Vehicle.Class = class
+		q.Price.CurrencyCode = "EUR"
+		q.Price.High = high
+		return q
+	}
+	original := newQuote("old", "fleet", "Saloon", 3000)
+	quotes := []Quote{
+		newQuote("other-fleet", "other", "Saloon", 2000),
+		newQuote("other-class", "fleet", "MPV", 2500),
+		newQuote("pricier", "fleet", "Saloon", 3200),
+		newQuote("same", "fleet", "Saloon", 3100),
+	}
+
+	q, err := FindEquivalentQuote(original, quotes, FareTolerance{Percent: 10})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if q.ID != "same" {
+		t.Errorf("expected cheapest equivalent quote, got %s", q.ID)
+	}
+	if change := NewPriceChange(original, *q); change.Difference() != 100 || !change.Changed() {
+		t.Errorf("unexpected price change %v", change)
+	}
+
+	_, err = FindEquivalentQuote(original, quotes, FareTolerance{Amount: 50})
+	if !errors.Is(err, ErrNoEquivalentQuote) {
+		t.Errorf("expected no equivalent quote within tolerance, got %v", err)
+	}
+}
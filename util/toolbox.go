@@ -2,17 +2,45 @@ package util
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
-// GetProjectRoot get project root path
+// Client http client making all karhoo calls, replace it to change the transport, e.g. with a CircuitBreakerTransport
+var Client = http.DefaultClient
+
+// GetProjectRoot get project root path, the working directory unless GOPROJECTROOT is set
 func GetProjectRoot() string {
-	return os.Getenv("GOPROJECTROOT")
+	if root := os.Getenv("GOPROJECTROOT"); root != "" {
+		return root
+	}
+	return "."
+}
+
+// DataDir directory of the files the CLI keeps between commands: the project root if GOPROJECTROOT is set, else the
+// karhoo directory of the user config dir, e.g. ~/.config/karhoo, falling back to the working directory
+func DataDir() string {
+	if root := os.Getenv("GOPROJECTROOT"); root != "" {
+		return root
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		dir = filepath.Join(dir, "karhoo")
+		if err := os.MkdirAll(dir, 0700); err == nil {
+			return dir
+		}
+	}
+	return "."
 }
 
 // RetrieveCredentials retrieves username and password from yaml config file
@@ -32,8 +60,36 @@ func RetrieveCredentials() (*Credentials, error) {
 	return c, nil
 }
 
-// PostRequest generic http post request
-func PostRequest(url string, authInfo *AuthInfo, postData map[string]interface{}) (*http.Response, error) {
+// authInfoFile where the CLI keeps the access token between commands
+func authInfoFile() string {
+	return filepath.Join(DataDir(), ".karhoo-token.json")
+}
+
+// SaveAuthInfo stores auth info so that later commands can reuse the access token, readable by the owner only
+func SaveAuthInfo(a *AuthInfo) error {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(authInfoFile(), b, 0600)
+}
+
+// LoadAuthInfo loads auth info stored by SaveAuthInfo, returns an error satisfying os.IsNotExist if there is none
+func LoadAuthInfo() (*AuthInfo, error) {
+	b, err := ioutil.ReadFile(authInfoFile())
+	if err != nil {
+		return nil, err
+	}
+	var a *AuthInfo
+	err = json.Unmarshal(b, &a)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// PostRequest generic http post request, postData is marshalled to json
+func PostRequest(url string, authInfo *AuthInfo, postData interface{}) (*http.Response, error) {
 	postBody, err := json.Marshal(postData)
 	if err != nil {
 		return nil, err
@@ -42,6 +98,10 @@ func PostRequest(url string, authInfo *AuthInfo, postData map[string]interface{}
 	if err != nil {
 		return nil, err
 	}
+	err = waitForRateLimit(req)
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
@@ -50,7 +110,7 @@ func PostRequest(url string, authInfo *AuthInfo, postData map[string]interface{}
 		req.Header.Add("Authorization", "Bearer "+authInfo.AccessToken)
 	}
 
-	return http.DefaultClient.Do(req)
+	return doRequest(req, authInfo, postData, postBody)
 }
 
 // GetRequest generic http get request
@@ -59,6 +119,10 @@ func GetRequest(url string, authInfo *AuthInfo) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = waitForRateLimit(req)
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
@@ -67,13 +131,109 @@ func GetRequest(url string, authInfo *AuthInfo) (*http.Response, error) {
 		req.Header.Add("Authorization", "Bearer "+authInfo.AccessToken)
 	}
 
-	return http.DefaultClient.Do(req)
+	return doRequest(req, authInfo, nil, nil)
 }
 
-// PrintStruct prints out a struct in a json human readable format
+// doRequest makes the call with Client, logs it and records it in Auditor
+func doRequest(req *http.Request, authInfo *AuthInfo, postData interface{}, payload []byte) (*http.Response, error) {
+	start := time.Now()
+	resp, err := Client.Do(req)
+	latency := time.Since(start)
+	fields := append([]Field{
+		LogField("method", req.Method),
+		LogField("endpoint", EndpointKey(req.Method, req.URL.String())),
+		LogField("latency_ms", latency.Milliseconds()),
+	}, authInfo.LogFields()...)
+	switch {
+	case err != nil:
+		LogEvent(LevelWarn, "karhoo call failed", append(fields, LogField("error", err))...)
+		err = &TransportError{Err: err}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		LogEvent(LevelWarn, "karhoo call failed", append(fields, LogField("status", resp.StatusCode))...)
+	default:
+		LogEvent(LevelDebug, "karhoo call", append(fields, LogField("status", resp.StatusCode))...)
+	}
+	if Auditor == nil {
+		return resp, err
+	}
+	return audit(req, authInfo, postData, payload, start, latency, resp, err)
+}
+
+// audit records a call in Auditor. The response body is read to find the IDs of created bookings and quotes lists and
+// handed back unread
+func audit(req *http.Request, authInfo *AuthInfo, postData interface{}, payload []byte, start time.Time,
+	latency time.Duration, resp *http.Response, err error) (*http.Response, error) {
+	entry := AuditEntry{
+		Time:      start,
+		Endpoint:  EndpointKey(req.Method, req.URL.String()),
+		URL:       req.URL.String(),
+		Actor:     Auditor.Actor,
+		LatencyMs: latency.Milliseconds(),
+	}
+	if authInfo != nil && authInfo.Actor != "" {
+		entry.Actor = authInfo.Actor
+	}
+	if postData != nil {
+		entry.Payload, _ = Redact(postData, RedactProduction)
+	}
+	var body []byte
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.StatusCode = resp.StatusCode
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			entry.Error = err.Error()
+		}
+	}
+	entry.BookingID, entry.QuoteID = AuditIDs(req.Method, req.URL.String(), payload, body)
+	if auditErr := Auditor.Append(entry); auditErr != nil {
+		LogEvent(LevelError, "recording in the audit log failed", append(authInfo.LogFields(), LogField("error", auditErr))...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// waitForRateLimit blocks until Limiter allows the request
+func waitForRateLimit(req *http.Request) error {
+	if Limiter == nil {
+		return nil
+	}
+	return Limiter.Wait(context.Background(), EndpointKey(req.Method, req.URL.String()))
+}
+
+// PrintStruct prints out a struct in a json human readable format, with personal data and secrets masked according
+// to LogRedaction
 func PrintInterface(s interface{}) {
-	m, err := json.MarshalIndent(s, "", "	")
-	if err == nil {
-		log.Println(string(m))
+	redacted, err := Redact(s, LogRedaction)
+	if err != nil {
+		return
+	}
+	var m bytes.Buffer
+	if json.Indent(&m, redacted, "", "	") == nil {
+		log.Println(m.String())
+	}
+}
+
+// GenerateID generates a random hex identifier for locally created records
+func GenerateID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// FormatPrice formats an amount in the smallest currency unit, e.g. 2550 EUR becomes "25.50 EUR"
+func FormatPrice(amount int, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
 	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, currency)
 }
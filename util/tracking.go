@@ -0,0 +1,79 @@
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTrackingInterval how often a tracking stream polls karhoo unless told otherwise
+const DefaultTrackingInterval = time.Second * 10
+
+// DriverTracking position and ETAs of the driver of a booking
+type DriverTracking struct {
+	Position  Position `json:"position"`
+	Direction struct {
+		Kph     int `json:"kph"`
+		Heading int `json:"heading"`
+	} `json:"direction"`
+	// OriginETA minutes until the driver reaches the pickup
+	OriginETA int `json:"origin_eta"`
+	// DestinationETA minutes until the driver reaches the destination
+	DestinationETA int `json:"destination_eta"`
+}
+
+// TrackingUpdate pushed by a tracking stream, Tracking is nil while no driver is assigned
+type TrackingUpdate struct {
+	BookingID string          `json:"booking_id"`
+	Status    BookingStatus   `json:"status"`
+	Tracking  *DriverTracking `json:"tracking"`
+	At        time.Time       `json:"at"`
+	Err       error           `json:"-"`
+}
+
+// HasDriverPosition checks if karhoo tracks the driver of a booking in this status
+func (s BookingStatus) HasDriverPosition() bool {
+	return s == BookingDriverEnRoute || s == BookingArrived || s == BookingPOB
+}
+
+// StreamTracking pushes the status and driver position of a booking on the returned channel every interval until the
+// trip ends or ctx is cancelled, the channel is closed afterwards. getDetails and track fetch the booking and the
+// driver position. A failed fetch is pushed as an update with Err set and retried after interval, so the stream
+// carries on once karhoo answers again
+func StreamTracking(ctx context.Context, bookingID string, interval time.Duration,
+	getDetails func() (*BookingDetails, error), track func() (*DriverTracking, error)) <-chan TrackingUpdate {
+	if interval <= 0 {
+		interval = DefaultTrackingInterval
+	}
+	updates := make(chan TrackingUpdate)
+	go func() {
+		defer close(updates)
+		for {
+			update := TrackingUpdate{BookingID: bookingID, At: time.Now()}
+			bookingDetails, err := getDetails()
+			if err != nil {
+				update.Err = err
+			} else {
+				update.BookingID = bookingDetails.ID
+				update.Status = bookingDetails.Status
+				// the driver position is only known once a driver is on the way
+				if bookingDetails.Status.HasDriverPosition() {
+					update.Tracking, update.Err = track()
+				}
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+			if update.Status.IsTerminal() {
+				return
+			}
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// trackingTestServer answers booking lookups with the given statuses in turn, 0 answers 503 like a failing karhoo
+func trackingTestServer(statuses ...BookingStatus) *httptest.Server {
+	var mutex sync.Mutex
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bookings/booking-1", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		status := statuses[calls]
+		if calls < len(statuses)-1 {
+			calls++
+		}
+		mutex.Unlock()
+		if status == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(BookingDetails{ID: "booking-1", Status: status})
+	})
+	mux.HandleFunc("/bookings/booking-1/track", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(DriverTracking{Position: Position{Latitude: 50.05, Longitude: 8.6}, OriginETA: 4})
+	})
+	return httptest.NewServer(mux)
+}
+
+func getTestJSON(url string, v interface{}) error {
+	res, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered %d", url, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func streamTestTracking(ctx context.Context, server *httptest.Server) <-chan TrackingUpdate {
+	return StreamTracking(ctx, "booking-1", time.Millisecond,
+		func() (*BookingDetails, error) {
+			var d BookingDetails
+			return &d, getTestJSON(server.URL+"/bookings/booking-1", &d)
+		},
+		func() (*DriverTracking, error) {
+			var t DriverTracking
+			return &t, getTestJSON(server.URL+"/bookings/booking-1/track", &t)
+		})
+}
+
+func TestStreamTracking(t *testing.T) {
+	server := trackingTestServer(BookingConfirmed, "", BookingDriverEnRoute, BookingCompleted)
+	defer server.Close()
+
+	var updates []TrackingUpdate
+	for update := range streamTestTracking(context.Background(), server) {
+		updates = append(updates, update)
+	}
+	// the failed lookup is reported and the stream carries on, the completed trip ends it
+	if len(updates) != 4 {
+		t.Fatalf("expected 4 updates before the stream ended, got %+v", updates)
+	}
+	if updates[0].Status != BookingConfirmed || updates[0].Tracking != nil || updates[0].Err != nil {
+		t.Errorf("expected a confirmed booking without driver position, got %+v", updates[0])
+	}
+	if updates[1].Err == nil || updates[1].BookingID != "booking-1" {
+		t.Errorf("expected the failed lookup of booking-1, got %+v", updates[1])
+	}
+	if updates[2].Status != BookingDriverEnRoute || updates[2].Tracking == nil || updates[2].Tracking.OriginETA != 4 {
+		t.Errorf("expected the driver position once the driver is en route, got %+v", updates[2])
+	}
+	if updates[3].Status != BookingCompleted || updates[3].Err != nil {
+		t.Errorf("expected the completed trip last, got %+v", updates[3])
+	}
+}
+
+func TestStreamTrackingCancel(t *testing.T) {
+	server := trackingTestServer(BookingDriverEnRoute)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := streamTestTracking(ctx, server)
+	if update := <-updates; update.Tracking == nil {
+		t.Fatalf("expected a driver position, got %+v", update)
+	}
+	cancel()
+	// an update may be in flight when the stream sees the cancellation, the channel is closed right after
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("expected cancelling to end the stream")
+		}
+	}
+}
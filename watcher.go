@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// bookingWatcher polls getBookingDetails for active bookings, for deployments that can not receive webhooks
+type bookingWatcher struct {
+	auth      *util.AuthInfo
+	intervals util.PollIntervals
+	events    chan util.BookingEvent
+
+	mutex   sync.Mutex
+	cancels map[string]context.CancelFunc
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// newBookingWatcher creates a watcher polling with the given intervals, the auth info is refreshed when it expires
+func newBookingWatcher(a *util.AuthInfo, intervals util.PollIntervals) *bookingWatcher {
+	return &bookingWatcher{
+		auth:      a,
+		intervals: intervals,
+		events:    make(chan util.BookingEvent),
+		cancels:   map[string]context.CancelFunc{},
+	}
+}
+
+// Events change events of all watched bookings, the channel is closed by Stop
+func (w *bookingWatcher) Events() <-chan util.BookingEvent {
+	return w.events
+}
+
+// Watch starts polling a booking until it reaches a terminal status or is unwatched
+func (w *bookingWatcher) Watch(bookingID string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.stopped {
+		return
+	}
+	if _, ok := w.cancels[bookingID]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancels[bookingID] = cancel
+	w.wg.Add(1)
+	go w.poll(ctx, bookingID)
+}
+
+// Unwatch stops polling a booking
+func (w *bookingWatcher) Unwatch(bookingID string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if cancel, ok := w.cancels[bookingID]; ok {
+		cancel()
+		delete(w.cancels, bookingID)
+	}
+}
+
+// Watching returns the IDs of all bookings being polled
+func (w *bookingWatcher) Watching() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ids := make([]string, 0, len(w.cancels))
+	for id := range w.cancels {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Stop stops polling all bookings and closes the events channel
+func (w *bookingWatcher) Stop() {
+	w.mutex.Lock()
+	if w.stopped {
+		w.mutex.Unlock()
+		return
+	}
+	w.stopped = true
+	for id, cancel := range w.cancels {
+		cancel()
+		delete(w.cancels, id)
+	}
+	w.mutex.Unlock()
+	w.wg.Wait()
+	close(w.events)
+}
+
+func (w *bookingWatcher) poll(ctx context.Context, bookingID string) {
+	defer w.wg.Done()
+	var previous *util.BookingDetails
+	for {
+		wait := w.intervals.Active
+		bookingDetails, err := w.getBookingDetails(bookingID)
+		if err != nil {
+			util.LogEvent(util.LevelWarn, "getting booking failed, retrying", util.LogField("booking_id", bookingID),
+				util.LogField("retry_in", wait), util.LogField("error", err))
+			w.emit(ctx, util.BookingEvent{BookingID: bookingID, Err: err})
+		} else {
+			// an invalid transition is reported but does not stop the watcher, karhoo's status is still the truth
+			_, err = bookingStates.Observe(bookingID, bookingDetails.Status)
+			if err != nil {
+				w.emit(ctx, util.BookingEvent{BookingID: bookingID, Details: bookingDetails, Err: err})
+			}
+			if previous == nil {
+				w.emit(ctx, util.BookingEvent{BookingID: bookingID, Details: bookingDetails})
+			} else if changes := util.DiffBookings(previous, bookingDetails); len(changes) > 0 {
+				w.emit(ctx, util.BookingEvent{BookingID: bookingID, Details: bookingDetails, Changes: changes})
+			}
+			previous = bookingDetails
+			if bookingDetails.Status.IsTerminal() {
+				w.Unwatch(bookingID)
+				return
+			}
+			wait = w.intervals.Next(bookingDetails, time.Now())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// getBookingDetails refreshes the shared access token if needed and gets the booking details with a copy of it
+func (w *bookingWatcher) getBookingDetails(bookingID string) (*util.BookingDetails, error) {
+	a, err := freshAuthInfo(w.auth)
+	if err != nil {
+		return nil, err
+	}
+	return getBookingDetails(a, bookingID)
+}
+
+func (w *bookingWatcher) emit(ctx context.Context, e util.BookingEvent) {
+	select {
+	case w.events <- e:
+	case <-ctx.Done():
+	}
+}
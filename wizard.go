@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"karhooAPIs.com/util"
+)
+
+// errWizardAborted the agent quit the wizard, nothing was booked
+var errWizardAborted = errors.New("booking wizard aborted")
+
+// wizard interactive booking walk through for support desk agents, reading answers line by line
+type wizard struct {
+	in   *bufio.Reader
+	out  io.Writer
+	auth *util.AuthInfo
+}
+
+func runWizard(args []string) int {
+	fs := newFlagSet("wizard")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	a, code := cliAuthInfo()
+	if code != exitOK {
+		return code
+	}
+	w := &wizard{in: bufio.NewReader(os.Stdin), out: os.Stdout, auth: a}
+	err := w.run()
+	if errors.Is(err, errWizardAborted) {
+		fmt.Fprintln(w.out, "nothing was booked")
+		return exitOK
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return exitOK
+}
+
+func (w *wizard) run() error {
+	fmt.Fprintln(w.out, "karhoo booking wizard, enter q at any prompt to quit")
+	origin, err := w.askLocation("Pickup address")
+	if err != nil {
+		return err
+	}
+	destination, err := w.askLocation("Destination address")
+	if err != nil {
+		return err
+	}
+	pickupTime, err := w.prompt("Pickup time, e.g. 2021-01-08T10:30 (empty for now)")
+	if err != nil {
+		return err
+	}
+	err = checkRouteCoverage(w.auth, *origin, *destination, pickupTime)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w.out, "requesting quotes...")
+	quotesList, err := getQuotesCached(w.auth, *origin, *destination, pickupTime)
+	if err != nil {
+		return err
+	}
+	quote, err := w.askQuote(quotesList)
+	if err != nil {
+		return err
+	}
+	bookingRequest, err := w.askPassenger(quote)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w.out)
+	fmt.Fprintf(w.out, "%s -> %s\n", origin.DisplayAddress, destination.DisplayAddress)
+	fmt.Fprintf(w.out, "%s with %s, %s\n", quote.Vehicle.Class, quote.Fleet.Name, describeQuotePrice(quote))
+	passenger := bookingRequest.Passengers.PassengerDetails[0]
+	fmt.Fprintf(w.out, "for %s %s (%s), %d passengers\n", passenger.FirstName, passenger.LastName, passenger.PhoneNumber, bookingRequest.PassengerCount())
+	ok, err := w.confirm("Book this ride")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errWizardAborted
+	}
+	bookingDetails, priceChange, err := bookWithRequote(w.auth, bookingRequest, quote, *origin, *destination, pickupTime, util.FareTolerance{Percent: 10})
+	if err != nil {
+		return err
+	}
+	if priceChange != nil && priceChange.Changed() {
+		fmt.Fprintf(w.out, "the quote expired and was re-quoted, the price changed by up to %s\n",
+			util.FormatPrice(priceChange.Difference(), priceChange.CurrencyCode))
+	}
+	fmt.Fprintf(w.out, "booked, booking ID %s, trip ID %s\n", bookingDetails.ID, bookingDetails.DisplayTripID)
+	w.followBooking(bookingDetails.ID)
+	return nil
+}
+
+// prompt asks a question and returns the trimmed answer, errWizardAborted if the agent entered q
+func (w *wizard) prompt(label string) (string, error) {
+	fmt.Fprintf(w.out, "%s: ", label)
+	line, err := w.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", errWizardAborted
+	}
+	line = strings.TrimSpace(line)
+	if line == "q" {
+		return "", errWizardAborted
+	}
+	return line, nil
+}
+
+// choose lists numbered options and returns the index of the chosen one
+func (w *wizard) choose(label string, options []string) (int, error) {
+	for i, option := range options {
+		fmt.Fprintf(w.out, "  %d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := w.prompt(label)
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Fprintf(w.out, "enter a number between 1 and %d\n", len(options))
+	}
+}
+
+// askCount asks for an optional count until the answer is empty or a number, empty counts as 0
+func (w *wizard) askCount(label string) (int, error) {
+	for {
+		answer, err := w.prompt(label)
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 0 {
+			return n, nil
+		}
+		fmt.Fprintln(w.out, "enter a number or leave it empty")
+	}
+}
+
+func (w *wizard) confirm(label string) (bool, error) {
+	answer, err := w.prompt(label + " [y/N]")
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes"), nil
+}
+
+// askLocation looks up the typed address and lets the agent pick one of the suggestions
+func (w *wizard) askLocation(label string) (*util.Geolocation, error) {
+	for {
+		query, err := w.prompt(label)
+		if err != nil {
+			return nil, err
+		}
+		if query == "" {
+			continue
+		}
+		sessionToken := util.GenerateID()
+		suggestions, err := suggestAddresses(w.auth, query, nil, sessionToken)
+		if err != nil {
+			return nil, err
+		}
+		if len(suggestions) == 0 {
+			fmt.Fprintln(w.out, "no address found, try again")
+			continue
+		}
+		options := make([]string, len(suggestions))
+		for i, s := range suggestions {
+			options[i] = s.DisplayAddress
+		}
+		i, err := w.choose("Choose an address", options)
+		if err != nil {
+			return nil, err
+		}
+		return geolocationOfPlace(w.auth, suggestions[i].PlaceID, sessionToken)
+	}
+}
+
+// askQuote shows the quotes grouped by vehicle class and lets the agent pick a class and then a quote of it
+func (w *wizard) askQuote(quotesList *util.QuotesList) (util.Quote, error) {
+	groups := util.GroupQuotesByVehicleClass(quotesList.Quotes)
+	if len(groups) == 0 {
+		return util.Quote{}, errors.New("no quotes available for this route")
+	}
+	options := make([]string, len(groups))
+	for i, g := range groups {
+		cheapest := g.Cheapest()
+		options[i] = fmt.Sprintf("%-10s from %s, %d quotes", g.Class, util.FormatPrice(cheapest.Price.Low, cheapest.Price.CurrencyCode), len(g.Quotes))
+	}
+	i, err := w.choose("Choose a vehicle class", options)
+	if err != nil {
+		return util.Quote{}, err
+	}
+	quotes := groups[i].Quotes
+	options = make([]string, len(quotes))
+	for i, q := range quotes {
+		options[i] = fmt.Sprintf("%s, %s, up to %d passengers, pickup in %d-%d min",
+			q.Fleet.Name, describeQuotePrice(q), q.Vehicle.PassengerCapacity, q.Vehicle.QTA.LowMinutes, q.Vehicle.QTA.HighMinutes)
+	}
+	i, err = w.choose("Choose a quote", options)
+	if err != nil {
+		return util.Quote{}, err
+	}
+	return quotes[i], nil
+}
+
+func describeQuotePrice(q util.Quote) string {
+	if q.Price.Low == q.Price.High {
+		return util.FormatPrice(q.Price.Low, q.Price.CurrencyCode)
+	}
+	return util.FormatPrice(q.Price.Low, q.Price.CurrencyCode) + " - " + util.FormatPrice(q.Price.High, q.Price.CurrencyCode)
+}
+
+// askPassenger asks for passenger details until they pass validation for the quote
+func (w *wizard) askPassenger(quote util.Quote) (*util.BookingRequest, error) {
+	for {
+		var p util.Passenger
+		var flightNumber, trainNumber string
+		for _, question := range []struct {
+			label  string
+			answer *string
+		}{
+			{"First name", &p.FirstName},
+			{"Last name", &p.LastName},
+			{"Phone number, e.g. +15005550006", &p.PhoneNumber},
+			{"Email (optional)", &p.Email},
+		} {
+			answer, err := w.prompt(question.label)
+			if err != nil {
+				return nil, err
+			}
+			*question.answer = answer
+		}
+		additionalPassengers, err := w.askCount("Additional passengers (optional)")
+		if err != nil {
+			return nil, err
+		}
+		luggage, err := w.askCount("Luggage (optional)")
+		if err != nil {
+			return nil, err
+		}
+		// only ask for a flight number if the fleet can follow the flight
+		if quote.HasCapability(util.CapabilityFlightTracking) {
+			answer, err := w.prompt("Flight number (optional)")
+			if err != nil {
+				return nil, err
+			}
+			flightNumber = answer
+		}
+		// and for a train number if it can follow the train
+		if quote.HasCapability(util.CapabilityTrainTracking) {
+			answer, err := w.prompt("Train number (optional)")
+			if err != nil {
+				return nil, err
+			}
+			trainNumber = answer
+		}
+		builder := util.NewBookingRequestBuilder(quote).PassengerDetails(p).AdditionalPassengers(additionalPassengers).
+			Luggage(luggage)
+		if flightNumber != "" {
+			builder.FlightNumber(flightNumber)
+		}
+		if trainNumber != "" {
+			builder.TrainNumber(trainNumber)
+		}
+		bookingRequest, err := builder.Build()
+		if err == nil {
+			return bookingRequest, nil
+		}
+		fmt.Fprintln(w.out, err)
+		fmt.Fprintln(w.out, "please enter the passenger details again")
+	}
+}
+
+// followBooking prints status changes of the booking until the trip ends, or until looking it up fails for another
+// reason than karhoo being unreachable or failing
+func (w *wizard) followBooking(bookingID string) {
+	fmt.Fprintln(w.out, "following the booking, press ctrl-c to stop")
+	watcher := newBookingWatcher(w.auth, util.DefaultPollIntervals)
+	watcher.Watch(bookingID)
+	for event := range watcher.Events() {
+		if event.Err != nil {
+			fmt.Fprintln(w.out, "error:", event.Err)
+			// events with details report an unexpected status change, polling goes on
+			if event.Details == nil && !transientPollError(event.Err) {
+				fmt.Fprintln(w.out, "stopped following the booking, check it with karhoo status", bookingID)
+				go watcher.Stop()
+			}
+			continue
+		}
+		d := event.Details
+		line := fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), d.Status)
+		if d.Vehicle.Driver.FirstName != "" {
+			line += fmt.Sprintf(", driver %s %s", d.Vehicle.Driver.FirstName, d.Vehicle.Driver.LastName)
+		}
+		if d.Vehicle.VehicleLicensePlate != "" {
+			line += fmt.Sprintf(", %s (%s)", d.Vehicle.Description, d.Vehicle.VehicleLicensePlate)
+		}
+		fmt.Fprintln(w.out, line)
+		if d.Status.IsTerminal() {
+			go watcher.Stop()
+		}
+	}
+}